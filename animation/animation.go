@@ -0,0 +1,110 @@
+// Пакет animation загружает анимации из экспорта Aseprite и проигрывает их
+package animation
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// LoopMode - способ повтора клипа
+type LoopMode int
+
+const (
+	Loop     LoopMode = iota // После последнего кадра - снова первый
+	Once                     // Останавливается на последнем кадре
+	PingPong                 // Идёт вперёд, затем назад
+)
+
+// Frame - кадр листа
+type Frame struct {
+	Rect     image.Rectangle // Область кадра в изображении листа
+	Duration float64         // Длительность кадра (в секундах)
+}
+
+// Event - событие, которое срабатывает при входе в кадр клипа, например звук шага
+type Event struct {
+	Name  string
+	Frame int
+}
+
+// Clip - именованная анимация
+type Clip struct {
+	Name   string
+	Frames []Frame
+	Mode   LoopMode
+	Events []Event
+}
+
+// SheetData - описание листа без изображения, как оно хранится в JSON
+type SheetData struct {
+	ImagePath string           // Путь к изображению относительно JSON
+	Frames    []Frame          // Все кадры листа
+	Clips     map[string]*Clip // Клипы по имени
+}
+
+// Sheet - лист анимаций вместе с изображением
+type Sheet struct {
+	*SheetData
+
+	// Image - изображение листа; может быть частью страницы атласа
+	Image *ebiten.Image
+
+	// images - кадры, вырезанные из изображения один раз
+	images map[image.Rectangle]*ebiten.Image
+
+	// fallback - клип, который отдаётся вместо отсутствующего; есть только у заглушки
+	fallback *Clip
+
+	// version - растёт при каждой замене листа, чтобы аниматоры заново нашли свои клипы
+	version int
+}
+
+// NewSheet связывает описание листа с изображением.
+// Области кадров задаются относительно левого верхнего угла img, даже если img - часть атласа.
+func NewSheet(data *SheetData, img *ebiten.Image) *Sheet {
+	sheet := &Sheet{}
+	sheet.Replace(data, img)
+	return sheet
+}
+
+// Replace подменяет описание и изображение листа на месте, например при горячей перезагрузке.
+// Аниматоры, которые ссылаются на лист, переключаются на новые клипы со следующего кадра.
+func (s *Sheet) Replace(data *SheetData, img *ebiten.Image) {
+	s.SheetData = data
+	s.Image = img
+	s.fallback = nil
+	s.images = make(map[image.Rectangle]*ebiten.Image)
+	for _, frame := range data.Frames {
+		s.images[frame.Rect] = img.SubImage(frame.Rect.Add(img.Bounds().Min)).(*ebiten.Image)
+	}
+	s.version++
+}
+
+// NewPlaceholderSheet создаёт лист из одного кадра-заглушки,
+// который проигрывается вместо любого запрошенного клипа
+func NewPlaceholderSheet(img *ebiten.Image) *Sheet {
+	frame := Frame{Rect: image.Rectangle{Max: img.Bounds().Size()}, Duration: 0.1}
+	sheet := NewSheet(&SheetData{
+		Frames: []Frame{frame},
+		Clips:  map[string]*Clip{},
+	}, img)
+	sheet.fallback = &Clip{Frames: []Frame{frame}, Mode: Once}
+	return sheet
+}
+
+// Clip возвращает клип по имени или nil
+func (s *Sheet) Clip(name string) *Clip {
+	if clip, ok := s.Clips[name]; ok {
+		return clip
+	}
+	return s.fallback
+}
+
+// FrameImage возвращает изображение кадра
+func (s *Sheet) FrameImage(frame Frame) *ebiten.Image {
+	if img, ok := s.images[frame.Rect]; ok {
+		return img
+	}
+	return s.Image.SubImage(frame.Rect.Add(s.Image.Bounds().Min)).(*ebiten.Image)
+}
@@ -0,0 +1,182 @@
+// Пакет animation загружает анимации из экспорта Aseprite и проигрывает их
+package animation
+
+import (
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Animator проигрывает клипы одного листа и переключает их по состоянию объекта
+type Animator struct {
+	// Sheet - лист с клипами
+	Sheet *Sheet
+
+	// OnEvent вызывается при входе в кадр с событием
+	OnEvent func(name string)
+
+	// clip - текущий клип
+	clip *Clip
+
+	// name - имя запрошенного клипа; у заглушки оно не совпадает с clip.Name
+	name string
+
+	// version - версия листа, из которой взят clip
+	version int
+
+	// frame - индекс текущего кадра в клипе
+	frame int
+
+	// elapsed - время, проведённое в текущем кадре (в секундах)
+	elapsed float64
+
+	// backward - идёт ли маятниковый клип назад
+	backward bool
+
+	// finished - закончился ли однократный клип
+	finished bool
+}
+
+// NewAnimator создаёт аниматор и запускает указанный клип
+func NewAnimator(sheet *Sheet, clip string) *Animator {
+	a := &Animator{Sheet: sheet}
+	a.Play(clip)
+	return a
+}
+
+// Play переключает клип; если он уже играет, ничего не меняется
+func (a *Animator) Play(name string) {
+	if a.clip != nil && a.name == name {
+		return
+	}
+	a.Restart(name)
+}
+
+// Restart запускает клип с первого кадра, даже если он уже играет
+func (a *Animator) Restart(name string) {
+	clip := a.Sheet.Clip(name)
+	if clip == nil {
+		log.Printf("Клип анимации %q не найден", name)
+		return
+	}
+	a.clip = clip
+	a.name = name
+	a.version = a.Sheet.version
+	a.frame = 0
+	a.elapsed = 0
+	a.backward = false
+	a.finished = false
+	a.fireEvents()
+}
+
+// ClipName возвращает имя текущего клипа
+func (a *Animator) ClipName() string {
+	if a.clip == nil {
+		return ""
+	}
+	return a.name
+}
+
+// Frame возвращает индекс текущего кадра в клипе
+func (a *Animator) Frame() int {
+	return a.frame
+}
+
+// SetFrame переходит на кадр клипа, например при загрузке сохранения
+func (a *Animator) SetFrame(frame int) {
+	if a.clip != nil && frame >= 0 && frame < len(a.clip.Frames) {
+		a.frame = frame
+		a.elapsed = 0
+	}
+}
+
+// Finished сообщает, что однократный клип дошёл до конца
+func (a *Animator) Finished() bool {
+	return a.finished
+}
+
+// Update продвигает анимацию на один кадр игры
+func (a *Animator) Update() {
+	a.refresh()
+	if a.clip == nil || a.finished {
+		return
+	}
+
+	a.elapsed += 1.0 / 60.0
+	for !a.finished && a.elapsed >= a.clip.Frames[a.frame].Duration {
+		a.elapsed -= a.clip.Frames[a.frame].Duration
+		a.advance()
+		a.fireEvents()
+	}
+}
+
+// refresh находит текущий клип заново, если лист был заменён, и сохраняет позицию в нём
+func (a *Animator) refresh() {
+	if a.version == a.Sheet.version || a.name == "" {
+		return
+	}
+	a.version = a.Sheet.version
+	clip := a.Sheet.Clip(a.name)
+	if clip == nil {
+		log.Printf("Клип анимации %q пропал после перезагрузки листа", a.name)
+		a.clip = nil
+		return
+	}
+	a.clip = clip
+	if a.frame >= len(clip.Frames) {
+		a.frame = 0
+		a.elapsed = 0
+		a.backward = false
+	}
+}
+
+// advance переходит к следующему кадру с учётом способа повтора
+func (a *Animator) advance() {
+	last := len(a.clip.Frames) - 1
+	switch a.clip.Mode {
+	case Loop:
+		a.frame = (a.frame + 1) % len(a.clip.Frames)
+	case Once:
+		if a.frame < last {
+			a.frame++
+		} else {
+			a.finished = true
+		}
+	case PingPong:
+		if last == 0 {
+			return
+		}
+		if a.backward && a.frame == 0 || !a.backward && a.frame == last {
+			a.backward = !a.backward
+		}
+		if a.backward {
+			a.frame--
+		} else {
+			a.frame++
+		}
+	}
+}
+
+// fireEvents вызывает OnEvent для событий текущего кадра
+func (a *Animator) fireEvents() {
+	if a.OnEvent == nil || a.finished {
+		return
+	}
+	for _, event := range a.clip.Events {
+		if event.Frame == a.frame {
+			a.OnEvent(event.Name)
+		}
+	}
+}
+
+// Image возвращает изображение текущего кадра
+func (a *Animator) Image() *ebiten.Image {
+	if a.clip == nil {
+		// Клипа нет - показываем первый кадр листа, чтобы объект не пропал
+		if len(a.Sheet.Frames) == 0 {
+			return nil
+		}
+		return a.Sheet.FrameImage(a.Sheet.Frames[0])
+	}
+	return a.Sheet.FrameImage(a.clip.Frames[a.frame])
+}
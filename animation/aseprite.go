@@ -0,0 +1,191 @@
+// Пакет animation загружает анимации из экспорта Aseprite и проигрывает их
+package animation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// asepriteRect - прямоугольник в формате Aseprite
+type asepriteRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// asepriteFrame - кадр в формате Aseprite
+type asepriteFrame struct {
+	Filename string       `json:"filename"`
+	Frame    asepriteRect `json:"frame"`
+	Duration int          `json:"duration"` // Длительность кадра (в миллисекундах)
+}
+
+// asepriteTag - именованный диапазон кадров (frame tag)
+type asepriteTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"` // forward, reverse, pingpong, pingpong_reverse
+	Repeat    string `json:"repeat"`    // Количество повторов; пусто - бесконечно
+	Data      string `json:"data"`      // Пользовательские данные тега; здесь - события кадров
+}
+
+// asepriteFile - JSON, экспортированный Aseprite (File > Export Sprite Sheet)
+type asepriteFile struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		Image     string        `json:"image"`
+		FrameTags []asepriteTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// ParseAseprite разбирает JSON-экспорт Aseprite в описание листа.
+// Поддерживаются оба вида массива кадров: "Array" и "Hash" (в порядке имён файлов).
+//
+// Каждый тег кадров становится клипом. Повтор "1" делает клип однократным, направление pingpong -
+// маятниковым. События кадров задаются в пользовательских данных тега как "имя@кадр"
+// через запятую, где кадр отсчитывается от начала тега, например "step@1, step@3".
+func ParseAseprite(data []byte) (*SheetData, error) {
+	var file asepriteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("повреждённый JSON Aseprite: %w", err)
+	}
+
+	frames, err := parseFrames(file.Frames)
+	if err != nil {
+		return nil, err
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("в JSON Aseprite нет кадров")
+	}
+
+	sheet := &SheetData{
+		ImagePath: file.Meta.Image,
+		Clips:     make(map[string]*Clip),
+		Frames:    make([]Frame, len(frames)),
+	}
+	for i, f := range frames {
+		// Кадр без длительности длился бы бесконечно мало; Aseprite по умолчанию ставит 100 мс
+		if f.Duration <= 0 {
+			f.Duration = 100
+		}
+		sheet.Frames[i] = Frame{
+			Rect:     image.Rect(f.Frame.X, f.Frame.Y, f.Frame.X+f.Frame.W, f.Frame.Y+f.Frame.H),
+			Duration: float64(f.Duration) / 1000,
+		}
+	}
+
+	for _, tag := range file.Meta.FrameTags {
+		clip, err := parseTag(tag, sheet.Frames)
+		if err != nil {
+			return nil, err
+		}
+		sheet.Clips[clip.Name] = clip
+	}
+
+	// Лист без тегов - один зацикленный клип из всех кадров
+	if len(sheet.Clips) == 0 {
+		sheet.Clips[""] = &Clip{Frames: sheet.Frames, Mode: Loop}
+	}
+	return sheet, nil
+}
+
+// parseFrames разбирает кадры в виде массива или словаря
+func parseFrames(raw json.RawMessage) ([]asepriteFrame, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	if raw[0] == '[' {
+		var frames []asepriteFrame
+		if err := json.Unmarshal(raw, &frames); err != nil {
+			return nil, fmt.Errorf("повреждённый список кадров: %w", err)
+		}
+		return frames, nil
+	}
+
+	var byName map[string]asepriteFrame
+	if err := json.Unmarshal(raw, &byName); err != nil {
+		return nil, fmt.Errorf("повреждённый список кадров: %w", err)
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return frameNumber(names[i]) < frameNumber(names[j]) })
+
+	frames := make([]asepriteFrame, len(names))
+	for i, name := range names {
+		frames[i] = byName[name]
+	}
+	return frames, nil
+}
+
+// frameNumber извлекает номер кадра из имени вида "player 12.aseprite"
+func frameNumber(name string) int {
+	name = strings.TrimSuffix(name, ".aseprite")
+	name = strings.TrimSuffix(name, ".ase")
+	i := len(name)
+	for i > 0 && name[i-1] >= '0' && name[i-1] <= '9' {
+		i--
+	}
+	n, _ := strconv.Atoi(name[i:])
+	return n
+}
+
+// parseTag превращает тег кадров в клип
+func parseTag(tag asepriteTag, frames []Frame) (*Clip, error) {
+	if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+		return nil, fmt.Errorf("тег %q ссылается на кадры %d..%d из %d", tag.Name, tag.From, tag.To, len(frames))
+	}
+
+	clip := &Clip{
+		Name:   tag.Name,
+		Frames: append([]Frame(nil), frames[tag.From:tag.To+1]...),
+		Mode:   Loop,
+	}
+
+	switch tag.Direction {
+	case "", "forward":
+	case "reverse":
+		reverse(clip.Frames)
+	case "pingpong":
+		clip.Mode = PingPong
+	case "pingpong_reverse":
+		reverse(clip.Frames)
+		clip.Mode = PingPong
+	default:
+		return nil, fmt.Errorf("тег %q: неизвестное направление %q", tag.Name, tag.Direction)
+	}
+	if tag.Repeat == "1" {
+		clip.Mode = Once
+	}
+
+	for _, item := range strings.Split(tag.Data, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, index, ok := strings.Cut(item, "@")
+		frame, err := strconv.Atoi(index)
+		if !ok || err != nil || frame < 0 || frame >= len(clip.Frames) {
+			return nil, fmt.Errorf("тег %q: неверное событие %q", tag.Name, item)
+		}
+		clip.Events = append(clip.Events, Event{Name: name, Frame: frame})
+	}
+	return clip, nil
+}
+
+// reverse разворачивает кадры в обратном порядке
+func reverse(frames []Frame) {
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+}
@@ -0,0 +1,309 @@
+// Пакет assets хранит ресурсы игры внутри исполняемого файла и выдаёт их по ключу.
+// Ключ - путь относительно каталога assets, например "coin.json" или "audio/hit.wav".
+package assets
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/png" // Для поддержки PNG изображений
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"superpupergame/animation"
+	"superpupergame/atlas"
+)
+
+// DefaultFont - шрифт, который встроен всегда и подставляется вместо отсутствующего
+const DefaultFont = "fonts/Go-Regular.ttf"
+
+// MonoFont - моноширинный шрифт для таблиц и отладки
+const MonoFont = "fonts/Go-Mono.ttf"
+
+// placeholderSize - размер текстуры-заглушки в пикселях
+const placeholderSize = 16
+
+// placeholderName - имя заглушки в атласе
+const placeholderName = "<placeholder>"
+
+//go:embed *.png *.json audio fonts locales
+var embedded embed.FS
+
+// sheetEntry - лист анимаций и ключ изображения, от которого он зависит
+type sheetEntry struct {
+	sheet *animation.Sheet
+	image string
+}
+
+// Manager загружает ресурсы и кэширует их по ключу
+type Manager struct {
+	// Dir - каталог, файлы из которого заменяют встроенные; пустая строка отключает замену
+	Dir string
+
+	// Atlas - общие страницы, на которые упаковываются все загруженные изображения
+	Atlas *atlas.Atlas
+
+	// files - встроенные ресурсы
+	files fs.FS
+
+	// sheets - загруженные листы анимаций по ключу
+	sheets map[string]*sheetEntry
+
+	// errs - ошибки загрузки по ключу; вместо таких ресурсов выдаются заглушки
+	errs map[string]error
+
+	// fonts - загруженные шрифты по ключу
+	fonts map[string]*text.GoTextFaceSource
+
+	// placeholder - спрайт, который рисуется вместо отсутствующего
+	placeholder *atlas.Sprite
+}
+
+// NewManager создаёт менеджер ресурсов; dir - необязательный каталог для замены встроенных файлов
+func NewManager(dir string) *Manager {
+	return &Manager{
+		Dir:    dir,
+		Atlas:  atlas.New(atlas.DefaultPageSize),
+		files:  embedded,
+		sheets: make(map[string]*sheetEntry),
+		errs:   make(map[string]error),
+		fonts:  make(map[string]*text.GoTextFaceSource),
+	}
+}
+
+// key приводит имя ресурса к ключу кэша
+func key(name string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+}
+
+// ReadFile читает ресурс: сначала из каталога замены, затем из встроенных файлов.
+// Через него же читаются звуки и музыка.
+func (m *Manager) ReadFile(name string) ([]byte, error) {
+	k := key(name)
+	if m.Dir != "" {
+		data, err := os.ReadFile(filepath.Join(m.Dir, filepath.FromSlash(k)))
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("ресурс %s: %w", k, err)
+		}
+	}
+	data, err := fs.ReadFile(m.files, k)
+	if err != nil {
+		return nil, fmt.Errorf("ресурс %s: %w", k, err)
+	}
+	return data, nil
+}
+
+// Sprite возвращает спрайт изображения по ключу; при первом обращении изображение упаковывается в атлас.
+// Если его нет или оно повреждено, под этим ключом в атлас кладётся заглушка
+// и возвращается вместе с ошибкой, чтобы отсутствие было видно на экране.
+func (m *Manager) Sprite(name string) (*atlas.Sprite, error) {
+	k := key(name)
+	if sprite := m.Atlas.Sprite(k); sprite != nil {
+		return sprite, m.errs[k]
+	}
+
+	img, err := m.decode(k)
+	if err != nil {
+		img = placeholderImage()
+		m.errs[k] = err
+	}
+	sprite, addErr := m.Atlas.Add(k, img)
+	if addErr != nil {
+		return m.Placeholder(), errors.Join(err, addErr)
+	}
+	return sprite, err
+}
+
+// decode читает и декодирует изображение
+func (m *Manager) decode(k string) (image.Image, error) {
+	data, err := m.ReadFile(k)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось декодировать изображение %s: %w", k, err)
+	}
+	return img, nil
+}
+
+// LoadAtlas заменяет атлас готовым, собранным заранее; страницы ищутся рядом с описанием.
+// Вызывается до загрузки остальных изображений, иначе они окажутся в старом атласе.
+func (m *Manager) LoadAtlas(name string) error {
+	k := key(name)
+	data, err := m.ReadFile(k)
+	if err != nil {
+		return err
+	}
+	loaded, err := atlas.LoadManifest(data, atlas.DefaultPageSize, func(page string) (image.Image, error) {
+		return m.decode(path.Join(path.Dir(k), page))
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", k, err)
+	}
+	m.Atlas = loaded
+	m.placeholder = nil
+	m.sheets = make(map[string]*sheetEntry)
+	m.errs = make(map[string]error)
+	return nil
+}
+
+// Sheet возвращает лист анимаций из JSON Aseprite; изображение ищется рядом с JSON.
+// При ошибке возвращается лист-заглушка, который проигрывается вместо любого клипа.
+func (m *Manager) Sheet(name string) (*animation.Sheet, error) {
+	k := key(name)
+	if entry, ok := m.sheets[k]; ok {
+		return entry.sheet, m.errs[k]
+	}
+
+	entry := &sheetEntry{}
+	data, img, err := m.loadSheet(k, entry)
+	if err != nil {
+		entry.sheet = animation.NewPlaceholderSheet(m.Placeholder().Image())
+		m.errs[k] = err
+	} else {
+		entry.sheet = animation.NewSheet(data, img)
+	}
+	m.sheets[k] = entry
+	return entry.sheet, err
+}
+
+// loadSheet читает и разбирает лист, записывая в entry ключ его изображения
+func (m *Manager) loadSheet(k string, entry *sheetEntry) (*animation.SheetData, *ebiten.Image, error) {
+	raw, err := m.ReadFile(k)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := animation.ParseAseprite(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", k, err)
+	}
+	entry.image = path.Join(path.Dir(k), data.ImagePath)
+	sprite, err := m.Sprite(entry.image)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, sprite.Image(), nil
+}
+
+// Reload перечитывает уже загруженный ресурс на месте: спрайты и листы остаются теми же объектами,
+// поэтому всё, что на них ссылается, сразу видит новую версию. Возвращает false, если ресурс не загружался.
+func (m *Manager) Reload(name string) (bool, error) {
+	k := key(name)
+	reloaded := false
+
+	if m.Atlas.Sprite(k) != nil {
+		reloaded = true
+		img, err := m.decode(k)
+		if err == nil {
+			err = m.Atlas.Replace(k, img)
+		}
+		if err != nil {
+			m.errs[k] = err
+			return true, err
+		}
+		delete(m.errs, k)
+	}
+
+	// Листы перечитываются и при правке JSON, и при правке их изображения
+	var errs []error
+	for sheetKey, entry := range m.sheets {
+		if sheetKey != k && entry.image != k {
+			continue
+		}
+		reloaded = true
+		data, img, err := m.loadSheet(sheetKey, entry)
+		if err != nil {
+			m.errs[sheetKey] = err
+			errs = append(errs, err)
+			continue
+		}
+		entry.sheet.Replace(data, img)
+		delete(m.errs, sheetKey)
+	}
+	return reloaded, errors.Join(errs...)
+}
+
+// Font возвращает источник шрифта TrueType/OpenType.
+// При ошибке возвращается встроенный DefaultFont, чтобы текст оставался читаемым.
+func (m *Manager) Font(name string) (*text.GoTextFaceSource, error) {
+	k := key(name)
+	if source, ok := m.fonts[k]; ok {
+		return source, nil
+	}
+
+	data, err := m.ReadFile(k)
+	if err == nil {
+		var source *text.GoTextFaceSource
+		source, err = text.NewGoTextFaceSource(bytes.NewReader(data))
+		if err == nil {
+			m.fonts[k] = source
+			return source, nil
+		}
+		err = fmt.Errorf("не удалось разобрать шрифт %s: %w", k, err)
+	}
+
+	if k == DefaultFont {
+		return nil, err
+	}
+	fallback, fallbackErr := m.defaultFont()
+	if fallbackErr != nil {
+		return nil, errors.Join(err, fallbackErr)
+	}
+	return fallback, err
+}
+
+// defaultFont загружает встроенный шрифт, минуя каталог замены
+func (m *Manager) defaultFont() (*text.GoTextFaceSource, error) {
+	if source, ok := m.fonts[DefaultFont]; ok {
+		return source, nil
+	}
+	data, err := fs.ReadFile(m.files, DefaultFont)
+	if err != nil {
+		return nil, fmt.Errorf("ресурс %s: %w", DefaultFont, err)
+	}
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось разобрать шрифт %s: %w", DefaultFont, err)
+	}
+	m.fonts[DefaultFont] = source
+	return source, nil
+}
+
+// Placeholder возвращает заметный спрайт-заглушку: пурпурно-чёрную шахматку
+func (m *Manager) Placeholder() *atlas.Sprite {
+	if m.placeholder != nil {
+		return m.placeholder
+	}
+	if sprite := m.Atlas.Sprite(placeholderName); sprite != nil {
+		m.placeholder = sprite
+		return sprite
+	}
+
+	// Имя заглушки не пересекается с ключами файлов, поэтому добавление не может не удаться
+	m.placeholder, _ = m.Atlas.Add(placeholderName, placeholderImage())
+	return m.placeholder
+}
+
+// placeholderImage рисует пурпурно-чёрную шахматку
+func placeholderImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, placeholderSize, placeholderSize))
+	draw.Draw(img, img.Bounds(), image.Black, image.Point{}, draw.Src)
+	magenta := image.NewUniform(color.RGBA{R: 255, B: 255, A: 255})
+	half := placeholderSize / 2
+	draw.Draw(img, image.Rect(0, 0, half, half), magenta, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(half, half, placeholderSize, placeholderSize), magenta, image.Point{}, draw.Src)
+	return img
+}
@@ -0,0 +1,265 @@
+// Пакет atlas упаковывает спрайты в общие страницы-текстуры,
+// чтобы ebiten мог объединять их отрисовку в один пакет
+package atlas
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultPageSize - сторона страницы атласа в пикселях
+const DefaultPageSize = 2048
+
+// padding - зазор между спрайтами, чтобы при фильтрации не просвечивали соседи
+const padding = 1
+
+// Sprite - именованный спрайт на странице атласа
+type Sprite struct {
+	// Name - имя, по которому спрайт ищется в атласе
+	Name string
+
+	// Page - номер страницы атласа
+	Page int
+
+	// Rect - область спрайта на странице
+	Rect image.Rectangle
+
+	// image - часть страницы, занятая спрайтом
+	image *ebiten.Image
+}
+
+// Image возвращает изображение спрайта; это часть общей страницы, а не отдельная текстура
+func (s *Sprite) Image() *ebiten.Image {
+	return s.image
+}
+
+// Size возвращает размер спрайта в пикселях
+func (s *Sprite) Size() (int, int) {
+	return s.Rect.Dx(), s.Rect.Dy()
+}
+
+// shelf - полка страницы: ряд спрайтов, выровненных по верхнему краю
+type shelf struct {
+	y, height int // Положение и высота полки
+	x         int // Где начинается свободное место на полке
+}
+
+// Page - страница атласа
+type Page struct {
+	// Image - текстура страницы
+	Image *ebiten.Image
+
+	// Sprites - сколько спрайтов лежит на странице
+	Sprites int
+
+	// used - площадь, занятая спрайтами (в пикселях)
+	used int
+
+	// shelves - заполненные полки сверху вниз
+	shelves []shelf
+
+	// closed - страница загружена готовой и не принимает новые спрайты
+	closed bool
+}
+
+// Occupancy возвращает долю площади страницы, занятую спрайтами (от 0 до 1)
+func (p *Page) Occupancy() float64 {
+	size := p.Image.Bounds().Size()
+	return float64(p.used) / float64(size.X*size.Y)
+}
+
+// place ищет на странице место для прямоугольника w x h методом полок
+func (p *Page) place(w, h int) (image.Point, bool) {
+	if p.closed {
+		return image.Point{}, false
+	}
+	size := p.Image.Bounds().Size()
+
+	// Сначала пробуем уже открытые полки подходящей высоты
+	for i := range p.shelves {
+		s := &p.shelves[i]
+		if h <= s.height && s.x+w <= size.X {
+			pos := image.Pt(s.x, s.y)
+			s.x += w
+			return pos, true
+		}
+	}
+
+	// Затем открываем новую полку под последней
+	y := 0
+	if n := len(p.shelves); n > 0 {
+		y = p.shelves[n-1].y + p.shelves[n-1].height
+	}
+	if y+h > size.Y || w > size.X {
+		return image.Point{}, false
+	}
+	p.shelves = append(p.shelves, shelf{y: y, height: h, x: w})
+	return image.Pt(0, y), true
+}
+
+// Atlas - набор страниц со спрайтами, доступными по имени
+type Atlas struct {
+	// PageSize - сторона новых страниц в пикселях
+	PageSize int
+
+	// pages - страницы в порядке создания
+	pages []*Page
+
+	// sprites - спрайты по имени
+	sprites map[string]*Sprite
+}
+
+// New создаёт пустой атлас; страницы создаются по мере добавления спрайтов
+func New(pageSize int) *Atlas {
+	return &Atlas{
+		PageSize: pageSize,
+		sprites:  make(map[string]*Sprite),
+	}
+}
+
+// Add копирует изображение на свободное место в атласе и возвращает его спрайт.
+// Изображение больше страницы получает собственную страницу.
+func (a *Atlas) Add(name string, img image.Image) (*Sprite, error) {
+	if _, ok := a.sprites[name]; ok {
+		return nil, fmt.Errorf("спрайт %q уже есть в атласе", name)
+	}
+	if img.Bounds().Empty() {
+		return nil, fmt.Errorf("спрайт %q пустой", name)
+	}
+
+	sprite := &Sprite{Name: name}
+	a.place(sprite, img)
+	a.sprites[name] = sprite
+	return sprite, nil
+}
+
+// Replace подменяет пиксели спрайта на месте, например при горячей перезагрузке.
+// Если размер изменился, спрайт переезжает на новое место, а старое остаётся пустым.
+func (a *Atlas) Replace(name string, img image.Image) error {
+	sprite, ok := a.sprites[name]
+	if !ok {
+		return fmt.Errorf("спрайта %q нет в атласе", name)
+	}
+	if img.Bounds().Empty() {
+		return fmt.Errorf("спрайт %q пустой", name)
+	}
+
+	if img.Bounds().Size() == sprite.Rect.Size() {
+		writePixels(sprite.image, img)
+		return nil
+	}
+
+	page := a.pages[sprite.Page]
+	page.used -= sprite.Rect.Dx() * sprite.Rect.Dy()
+	page.Sprites--
+	a.place(sprite, img)
+	return nil
+}
+
+// place находит место для изображения, копирует его туда и записывает положение в спрайт
+func (a *Atlas) place(sprite *Sprite, img image.Image) {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	index, pos := a.allocate(w+padding, h+padding)
+	page := a.pages[index]
+
+	sprite.Page = index
+	sprite.Rect = image.Rectangle{Min: pos, Max: pos.Add(image.Pt(w, h))}
+	sprite.image = page.Image.SubImage(sprite.Rect).(*ebiten.Image)
+	writePixels(sprite.image, img)
+
+	page.used += w * h
+	page.Sprites++
+}
+
+// writePixels копирует пиксели без промежуточной текстуры; ebiten ждёт RGBA с предумноженной альфой
+func writePixels(dst *ebiten.Image, img image.Image) {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	dst.WritePixels(rgba.Pix)
+}
+
+// allocate находит страницу и место для прямоугольника w x h, при необходимости создавая страницу
+func (a *Atlas) allocate(w, h int) (int, image.Point) {
+	for i, page := range a.pages {
+		if pos, ok := page.place(w, h); ok {
+			return i, pos
+		}
+	}
+
+	size := image.Pt(a.PageSize, a.PageSize)
+	if w > size.X || h > size.Y {
+		size = image.Pt(max(w, size.X), max(h, size.Y))
+	}
+	page := &Page{Image: ebiten.NewImage(size.X, size.Y)}
+	a.pages = append(a.pages, page)
+	pos, _ := page.place(w, h)
+	return len(a.pages) - 1, pos
+}
+
+// Sprite возвращает спрайт по имени или nil
+func (a *Atlas) Sprite(name string) *Sprite {
+	return a.sprites[name]
+}
+
+// Pages возвращает страницы атласа для отладочного просмотра
+func (a *Atlas) Pages() []*Page {
+	return a.pages
+}
+
+// Manifest - описание готового атласа: изображения страниц и области спрайтов
+type Manifest struct {
+	Pages   []string                  `json:"pages"`   // Пути к изображениям страниц
+	Sprites map[string]ManifestSprite `json:"sprites"` // Спрайты по имени
+}
+
+// ManifestSprite - положение спрайта в готовом атласе
+type ManifestSprite struct {
+	Page int `json:"page"`
+	X    int `json:"x"`
+	Y    int `json:"y"`
+	W    int `json:"w"`
+	H    int `json:"h"`
+}
+
+// LoadManifest создаёт атлас из готового описания; loadPage загружает изображение страницы по пути.
+// Готовые страницы не принимают новые спрайты, для них создаются новые страницы.
+func LoadManifest(data []byte, pageSize int, loadPage func(path string) (image.Image, error)) (*Atlas, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("повреждённое описание атласа: %w", err)
+	}
+
+	a := New(pageSize)
+	for _, path := range manifest.Pages {
+		img, err := loadPage(path)
+		if err != nil {
+			return nil, err
+		}
+		a.pages = append(a.pages, &Page{Image: ebiten.NewImageFromImage(img), closed: true})
+	}
+
+	for name, s := range manifest.Sprites {
+		if s.Page < 0 || s.Page >= len(a.pages) {
+			return nil, fmt.Errorf("спрайт %q: нет страницы %d", name, s.Page)
+		}
+		page := a.pages[s.Page]
+		rect := image.Rect(s.X, s.Y, s.X+s.W, s.Y+s.H)
+		if rect.Empty() || !rect.In(page.Image.Bounds()) {
+			return nil, fmt.Errorf("спрайт %q выходит за страницу %d", name, s.Page)
+		}
+		page.used += s.W * s.H
+		page.Sprites++
+		a.sprites[name] = &Sprite{
+			Name:  name,
+			Page:  s.Page,
+			Rect:  rect,
+			image: page.Image.SubImage(rect).(*ebiten.Image),
+		}
+	}
+	return a, nil
+}
@@ -0,0 +1,116 @@
+// Пакет camera отвечает за ощущение удара: тряску экрана, хитстоп и толчки камеры
+package camera
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/config"
+	"superpupergame/viewport"
+)
+
+// Параметры камеры
+const (
+	MaxShakeOffset = 16.0 // Наибольшее смещение при полной тряске (в пикселях)
+	TraumaDecay    = 1.6  // Сколько травмы уходит за секунду
+	KickDamping    = 0.82 // Доля толчка, остающаяся на следующем кадре
+)
+
+// Camera смещает отрисовку игрового мира. Тряска растёт с квадратом «травмы»,
+// поэтому слабые удары почти незаметны, а несколько подряд ощутимо раскачивают экран.
+type Camera struct {
+	// settings - настройки с интенсивностью тряски для доступности
+	settings *config.Settings
+
+	// trauma - текущая травма (0..1)
+	trauma float64
+
+	// kickX, kickY - затухающий направленный толчок
+	kickX, kickY float64
+
+	// hitstop - сколько кадров симуляция ещё заморожена
+	hitstop int
+
+	// time - время для плавного шума тряски (в секундах)
+	time float64
+
+	// world - буфер размером с арену, в который рисуется игровой мир перед смещением
+	world *ebiten.Image
+}
+
+// NewCamera создаёт камеру; интенсивность тряски берётся из settings.ScreenShake на каждом кадре
+func NewCamera(settings *config.Settings) *Camera {
+	return &Camera{settings: settings}
+}
+
+// AddTrauma добавляет травму; итоговая травма не превышает 1
+func (c *Camera) AddTrauma(amount float64) {
+	c.trauma = math.Min(1, c.trauma+amount)
+}
+
+// Kick толкает камеру в указанном направлении (в пикселях)
+func (c *Camera) Kick(dx, dy float64) {
+	c.kickX += dx
+	c.kickY += dy
+}
+
+// Hitstop замораживает симуляцию на указанное количество кадров
+func (c *Camera) Hitstop(ticks int) {
+	c.hitstop = max(c.hitstop, ticks)
+}
+
+// Frozen сообщает, заморожена ли симуляция хитстопом
+func (c *Camera) Frozen() bool {
+	return c.hitstop > 0
+}
+
+// Reset убирает тряску, толчки и хитстоп
+func (c *Camera) Reset() {
+	c.trauma = 0
+	c.kickX, c.kickY = 0, 0
+	c.hitstop = 0
+}
+
+// Update продвигает камеру на один кадр. Вызывается и во время хитстопа,
+// чтобы тряска продолжалась, пока мир стоит.
+func (c *Camera) Update() {
+	const dt = 1.0 / 60.0
+
+	c.time += dt
+	c.trauma = math.Max(0, c.trauma-TraumaDecay*dt)
+	c.kickX *= KickDamping
+	c.kickY *= KickDamping
+	if c.hitstop > 0 {
+		c.hitstop--
+	}
+}
+
+// Offset возвращает смещение мира на текущем кадре с учётом настройки доступности
+func (c *Camera) Offset() (float64, float64) {
+	intensity := c.settings.ScreenShake
+	shake := c.trauma * c.trauma * MaxShakeOffset
+
+	// Сумма синусов с несоизмеримыми частотами даёт плавный шум без повторов
+	noiseX := math.Sin(c.time*47) * math.Cos(c.time*29)
+	noiseY := math.Sin(c.time*41+1.3) * math.Cos(c.time*37+0.7)
+
+	return (noiseX*shake + c.kickX) * intensity, (noiseY*shake + c.kickY) * intensity
+}
+
+// DrawWorld рисует мир через буфер в координатах арены и вписывает его в экран со смещением камеры.
+// Всё, что рисуется на screen после DrawWorld (например HUD), не смещается.
+func (c *Camera) DrawWorld(screen *ebiten.Image, draw func(world *ebiten.Image)) {
+	if c.world == nil {
+		c.world = ebiten.NewImage(viewport.ArenaWidth, viewport.ArenaHeight)
+	}
+
+	c.world.Clear()
+	draw(c.world)
+
+	// Смещение задано в пикселях арены, поэтому применяется до масштабирования
+	x, y := c.Offset()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(math.Round(x), math.Round(y))
+	op.GeoM.Concat(viewport.Current().ArenaGeoM())
+	screen.DrawImage(c.world, op)
+}
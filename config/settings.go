@@ -0,0 +1,275 @@
+// Пакет config хранит настройки игры и сохраняет их между запусками
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/utils"
+	"superpupergame/viewport"
+)
+
+// fileName - имя файла настроек в каталоге настроек пользователя
+const fileName = "settings.json"
+
+// Способы подгонки логического экрана под окно
+const (
+	ScaleLetterbox = "letterbox" // Разрешение не меняется, по краям окна полосы
+	ScaleExpand    = "expand"    // Экран расширяется до пропорций окна
+)
+
+// Resolution - логическое разрешение экрана
+type Resolution struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// String возвращает разрешение в виде "1280x960"
+func (r Resolution) String() string {
+	return fmt.Sprintf("%dx%d", r.Width, r.Height)
+}
+
+// Resolutions - допустимые логические разрешения: 4:3 и 16:9
+var Resolutions = []Resolution{{1280, 960}, {1024, 768}, {1280, 720}, {1920, 1080}}
+
+// ScaleModes - допустимые способы подгонки экрана под окно
+var ScaleModes = []string{ScaleLetterbox, ScaleExpand}
+
+// WindowScales - допустимые масштабы окна
+var WindowScales = []float64{0.5, 0.75, 1.0, 1.25}
+
+// Languages - поддерживаемые языки интерфейса
+var Languages = []string{"en", "ru"}
+
+// KeyBindings - назначение клавиш для управления игроком
+type KeyBindings struct {
+	Up    ebiten.Key `json:"up"`
+	Down  ebiten.Key `json:"down"`
+	Left  ebiten.Key `json:"left"`
+	Right ebiten.Key `json:"right"`
+	Dash  ebiten.Key `json:"dash"`
+}
+
+// ReservedKeys - клавиши, которые нельзя назначить действиям игрока:
+// Escape (пауза и отмена), консоль разработчика и отладочные клавиши F1-F10
+var ReservedKeys = []ebiten.Key{
+	ebiten.KeyEscape, ebiten.KeyBackquote,
+	ebiten.KeyF1, ebiten.KeyF2, ebiten.KeyF3, ebiten.KeyF4, ebiten.KeyF5,
+	ebiten.KeyF6, ebiten.KeyF7, ebiten.KeyF8, ebiten.KeyF9, ebiten.KeyF10,
+}
+
+// Bindings возвращает указатели на назначения всех действий в порядке полей
+func (k *KeyBindings) Bindings() []*ebiten.Key {
+	return []*ebiten.Key{&k.Up, &k.Down, &k.Left, &k.Right, &k.Dash}
+}
+
+// ValidKey сообщает, можно ли назначить клавишу действию игрока
+func ValidKey(key ebiten.Key) bool {
+	return key >= 0 && key <= ebiten.KeyMax && !slices.Contains(ReservedKeys, key)
+}
+
+// DebugDefaults - состояние режима отладки при запуске
+type DebugDefaults struct {
+	Enabled       bool `json:"enabled"`
+	ShowFPS       bool `json:"show_fps"`
+	ShowHitboxes  bool `json:"show_hitboxes"`
+	ShowPositions bool `json:"show_positions"`
+}
+
+// HUDSettings - какие элементы HUD показываются во время игры
+type HUDSettings struct {
+	Health     bool `json:"health"`
+	Experience bool `json:"experience"`
+	Score      bool `json:"score"`
+	Dash       bool `json:"dash"`
+	Attack     bool `json:"attack"`
+	Wave       bool `json:"wave"`
+	Timer      bool `json:"timer"`
+	Combo      bool `json:"combo"`
+	Buffs      bool `json:"buffs"`
+}
+
+// Settings - все настройки игры
+type Settings struct {
+	MasterVolume float64       `json:"master_volume"` // Общая громкость (0..1)
+	MusicVolume  float64       `json:"music_volume"`  // Громкость музыки (0..1)
+	SFXVolume    float64       `json:"sfx_volume"`    // Громкость звуковых эффектов (0..1)
+	Fullscreen   bool          `json:"fullscreen"`
+	WindowScale  float64       `json:"window_scale"` // Масштаб окна относительно логического разрешения
+	Resolution   Resolution    `json:"resolution"`   // Логическое разрешение экрана
+	ScaleMode    string        `json:"scale_mode"`   // Подгонка экрана под окно: ScaleLetterbox или ScaleExpand
+	VSync        bool          `json:"vsync"`
+	ScreenShake  float64       `json:"screen_shake"` // Интенсивность тряски экрана (0 - выключена, 1 - полная)
+	Language     string        `json:"language"`
+	Keys         KeyBindings   `json:"keys"`
+	HUD          HUDSettings   `json:"hud"`
+	Debug        DebugDefaults `json:"debug"`
+
+	// path - путь к файлу настроек; пустой, если каталог настроек недоступен
+	path string
+}
+
+// Default возвращает настройки по умолчанию
+func Default() *Settings {
+	return &Settings{
+		MasterVolume: 1.0,
+		MusicVolume:  0.7,
+		SFXVolume:    0.8,
+		Fullscreen:   false,
+		WindowScale:  1.0,
+		Resolution:   Resolutions[0],
+		ScaleMode:    ScaleLetterbox,
+		VSync:        true,
+		ScreenShake:  1.0,
+		Language:     "en",
+		Keys: KeyBindings{
+			Up:    ebiten.KeyW,
+			Down:  ebiten.KeyS,
+			Left:  ebiten.KeyA,
+			Right: ebiten.KeyD,
+			Dash:  ebiten.KeySpace,
+		},
+		HUD: HUDSettings{
+			Health:     true,
+			Experience: true,
+			Score:      true,
+			Dash:       true,
+			Attack:     true,
+			Wave:       true,
+			Timer:      true,
+			Combo:      true,
+			Buffs:      true,
+		},
+		Debug: DebugDefaults{
+			ShowFPS:       true,
+			ShowHitboxes:  true,
+			ShowPositions: true,
+		},
+	}
+}
+
+// Load загружает настройки из каталога настроек пользователя.
+// Всегда возвращает пригодные настройки: при отсутствии файла - значения по умолчанию,
+// при повреждённом файле - значения по умолчанию вместе с ошибкой для журнала.
+func Load() (*Settings, error) {
+	settings := Default()
+
+	path, err := utils.ConfigPath(fileName)
+	if err != nil {
+		return settings, err
+	}
+	settings.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf("не удалось прочитать настройки: %w", err)
+	}
+
+	if err := json.Unmarshal(data, settings); err != nil {
+		settings = Default()
+		settings.path = path
+		return settings, fmt.Errorf("повреждённый файл настроек, используются значения по умолчанию: %w", err)
+	}
+	settings.Validate()
+	return settings, nil
+}
+
+// Save атомарно записывает настройки на диск
+func (s *Settings) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать настройки: %w", err)
+	}
+	return utils.WriteFileAtomic(s.path, data)
+}
+
+// Validate заменяет недопустимые значения на значения по умолчанию
+func (s *Settings) Validate() {
+	defaults := Default()
+
+	s.MasterVolume = validRatio(s.MasterVolume, defaults.MasterVolume)
+	s.MusicVolume = validRatio(s.MusicVolume, defaults.MusicVolume)
+	s.SFXVolume = validRatio(s.SFXVolume, defaults.SFXVolume)
+	s.ScreenShake = validRatio(s.ScreenShake, defaults.ScreenShake)
+
+	if IndexOf(WindowScales, s.WindowScale) < 0 {
+		s.WindowScale = defaults.WindowScale
+	}
+	if IndexOf(Resolutions, s.Resolution) < 0 {
+		s.Resolution = defaults.Resolution
+	}
+	if IndexOf(ScaleModes, s.ScaleMode) < 0 {
+		s.ScaleMode = defaults.ScaleMode
+	}
+	if IndexOf(Languages, s.Language) < 0 {
+		s.Language = defaults.Language
+	}
+	s.Keys.validate(&defaults.Keys)
+}
+
+// validate заменяет недопустимые и повторяющиеся клавиши значениями по умолчанию;
+// если после этого клавиши всё ещё повторяются, сбрасываются все назначения
+func (k *KeyBindings) validate(defaults *KeyBindings) {
+	keys, fallback := k.Bindings(), defaults.Bindings()
+	for i, key := range keys {
+		if !ValidKey(*key) || slices.ContainsFunc(keys[:i], func(other *ebiten.Key) bool { return *other == *key }) {
+			*key = *fallback[i]
+		}
+	}
+	for i, key := range keys {
+		if slices.ContainsFunc(keys[:i], func(other *ebiten.Key) bool { return *other == *key }) {
+			*k = *defaults
+			return
+		}
+	}
+}
+
+// WindowSize возвращает размер окна с учётом масштаба
+func (s *Settings) WindowSize() (int, int) {
+	return int(float64(s.Resolution.Width) * s.WindowScale), int(float64(s.Resolution.Height) * s.WindowScale)
+}
+
+// ApplyWindow применяет к окну и экрану игры настройки разрешения, подгонки,
+// полноэкранного режима, масштаба и вертикальной синхронизации
+func (s *Settings) ApplyWindow() {
+	screen := viewport.Current()
+	screen.Width, screen.Height = s.Resolution.Width, s.Resolution.Height
+	screen.Mode = viewport.Letterbox
+	if s.ScaleMode == ScaleExpand {
+		screen.Mode = viewport.Expand
+	}
+
+	ebiten.SetWindowSize(s.WindowSize())
+	ebiten.SetFullscreen(s.Fullscreen)
+	ebiten.SetVsyncEnabled(s.VSync)
+}
+
+// validRatio возвращает value, если оно в диапазоне [0, 1], иначе fallback
+func validRatio(value, fallback float64) float64 {
+	if math.IsNaN(value) || value < 0 || value > 1 {
+		return fallback
+	}
+	return value
+}
+
+// IndexOf возвращает индекс значения в списке или -1, если его там нет
+func IndexOf[T comparable](values []T, value T) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
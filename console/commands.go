@@ -0,0 +1,186 @@
+// Пакет console - консоль разработчика: реестр команд, разбор аргументов, история и автодополнение
+package console
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Command - команда консоли
+type Command struct {
+	// Name - имя, по которому команда вызывается
+	Name string
+
+	// Usage - аргументы для подсказки, например "<type> <n>"
+	Usage string
+
+	// Help - краткое описание для команды help
+	Help string
+
+	// Complete - варианты для аргумента с индексом len(args)-1 (args - уже введённые аргументы,
+	// последний может быть недописан); nil - аргументы не дополняются
+	Complete func(args []string) []string
+
+	// Run - выполняет команду; вывод печатается через c
+	Run func(c *Console, args []string) error
+}
+
+// ErrUsage возвращается командой, получившей неверные аргументы; консоль печатает подсказку
+var ErrUsage = errors.New("неверные аргументы")
+
+// commands - зарегистрированные команды по имени
+var commands = make(map[string]*Command)
+
+// Register добавляет команду в реестр. Пакеты регистрируют свои команды при создании объектов,
+// с которыми команды работают; команда с тем же именем заменяется.
+func Register(cmd Command) {
+	if _, ok := commands[cmd.Name]; ok {
+		log.Printf("Команда консоли %q зарегистрирована повторно", cmd.Name)
+	}
+	commands[cmd.Name] = &cmd
+}
+
+// Names возвращает имена всех команд по алфавиту
+func Names() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Lookup возвращает команду по имени
+func Lookup(name string) (*Command, bool) {
+	cmd, ok := commands[name]
+	return cmd, ok
+}
+
+// Parse разбивает строку на слова; слова в двойных кавычках могут содержать пробелы
+func Parse(line string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case r == ' ' && !quoted:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("незакрытая кавычка")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// Int разбирает аргумент args[i] как целое число; name - имя аргумента для сообщения об ошибке
+func Int(args []string, i int, name string) (int, error) {
+	if i >= len(args) {
+		return 0, fmt.Errorf("%w: не указан %s", ErrUsage, name)
+	}
+	n, err := strconv.Atoi(args[i])
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s должен быть целым числом, получено %q", ErrUsage, name, args[i])
+	}
+	return n, nil
+}
+
+// Float разбирает аргумент args[i] как число; name - имя аргумента для сообщения об ошибке
+func Float(args []string, i int, name string) (float64, error) {
+	if i >= len(args) {
+		return 0, fmt.Errorf("%w: не указан %s", ErrUsage, name)
+	}
+	x, err := strconv.ParseFloat(args[i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s должен быть числом, получено %q", ErrUsage, name, args[i])
+	}
+	return x, nil
+}
+
+// Choices возвращает функцию автодополнения, предлагающую варианты для аргумента с индексом i
+func Choices(i int, values ...string) func(args []string) []string {
+	return func(args []string) []string {
+		if len(args)-1 != i {
+			return nil
+		}
+		return values
+	}
+}
+
+// complete дополняет строку ввода: имя команды или её последний аргумент.
+// Возвращает новую строку и варианты, если подходящих несколько.
+func complete(line string) (string, []string) {
+	words, err := Parse(line)
+	if err != nil {
+		return line, nil
+	}
+	// Пробел в конце означает, что дополняется новый пустой аргумент
+	if len(words) == 0 || strings.HasSuffix(line, " ") {
+		words = append(words, "")
+	}
+
+	var candidates []string
+	if len(words) == 1 {
+		candidates = Names()
+	} else if cmd, ok := commands[words[0]]; ok && cmd.Complete != nil {
+		candidates = cmd.Complete(words[1:])
+	}
+
+	last := words[len(words)-1]
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, last) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return line, nil
+	}
+
+	// Дописываем общее начало вариантов; единственный вариант дописывается целиком с пробелом
+	words[len(words)-1] = commonPrefix(matches)
+	completed := strings.Join(words, " ")
+	if len(matches) == 1 {
+		return completed + " ", nil
+	}
+	return completed, matches
+}
+
+// commonPrefix возвращает общее начало строк
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,241 @@
+// Пакет console - консоль разработчика: реестр команд, разбор аргументов, история и автодополнение
+package console
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/text"
+	"superpupergame/ui"
+)
+
+// Параметры консоли
+const (
+	maxLines   = 500 // Сколько строк вывода хранится
+	maxHistory = 100 // Сколько введённых команд хранится в истории
+	lineHeight = 18  // Высота строки вывода в пикселях
+)
+
+// ToggleKey - клавиша, открывающая и закрывающая консоль
+const ToggleKey = ebiten.KeyBackquote
+
+// Console - выпадающая консоль разработчика. Пока она открыта, весь ввод с клавиатуры принадлежит ей.
+type Console struct {
+	// Open - открыта ли консоль
+	Open bool
+
+	// input - набираемая строка
+	input []rune
+
+	// lines - строки вывода, последняя - самая новая
+	lines []string
+
+	// scroll - на сколько строк вывод прокручен вверх
+	scroll int
+
+	// history - введённые команды, последняя - самая новая
+	history []string
+
+	// historyPos - позиция в истории при листании; len(history) - новая строка
+	historyPos int
+
+	// draft - строка, набранная до начала листания истории
+	draft []rune
+
+	// blink - счётчик кадров для мигания курсора
+	blink int
+}
+
+// New создаёт консоль и регистрирует встроенные команды help и clear
+func New() *Console {
+	c := &Console{}
+	Register(Command{
+		Name:     "help",
+		Usage:    "[command]",
+		Help:     "список команд или подсказка по команде",
+		Complete: func(args []string) []string { return Names() },
+		Run:      c.help,
+	})
+	Register(Command{
+		Name: "clear",
+		Help: "очистить вывод консоли",
+		Run: func(c *Console, args []string) error {
+			c.lines = nil
+			c.scroll = 0
+			return nil
+		},
+	})
+	return c
+}
+
+// Printf печатает строку в консоль и в журнал
+func (c *Console) Printf(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	log.Printf("Консоль: %s", message)
+	c.lines = append(c.lines, strings.Split(message, "\n")...)
+	if len(c.lines) > maxLines {
+		c.lines = c.lines[len(c.lines)-maxLines:]
+	}
+	c.scroll = 0
+}
+
+// Exec выполняет строку с командой и её аргументами
+func (c *Console) Exec(line string) {
+	args, err := Parse(line)
+	if err != nil {
+		c.Printf("Ошибка: %v", err)
+		return
+	}
+	if len(args) == 0 {
+		return
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		c.Printf("Неизвестная команда %q; список команд - help", args[0])
+		return
+	}
+	if err := cmd.Run(c, args[1:]); err != nil {
+		c.Printf("Ошибка: %v", err)
+		if errors.Is(err, ErrUsage) {
+			c.Printf("Использование: %s %s", cmd.Name, cmd.Usage)
+		}
+	}
+}
+
+// help печатает список команд или подсказку по одной команде
+func (c *Console) help(_ *Console, args []string) error {
+	if len(args) > 0 {
+		cmd, ok := commands[args[0]]
+		if !ok {
+			return fmt.Errorf("неизвестная команда %q", args[0])
+		}
+		c.Printf("%s %s - %s", cmd.Name, cmd.Usage, cmd.Help)
+		return nil
+	}
+	for _, name := range Names() {
+		cmd := commands[name]
+		c.Printf("  %-24s %s", strings.TrimSpace(cmd.Name+" "+cmd.Usage), cmd.Help)
+	}
+	return nil
+}
+
+// Update открывает и закрывает консоль и, пока она открыта, обрабатывает ввод.
+// Возвращает true, если консоль открыта и ввод не должен доходить до игры.
+func (c *Console) Update() bool {
+	if inpututil.IsKeyJustPressed(ToggleKey) {
+		c.Open = !c.Open
+		c.blink = 0
+		return true
+	}
+	if !c.Open {
+		return false
+	}
+	c.blink++
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		c.Open = false
+		return true
+	}
+
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if unicode.IsPrint(r) {
+			c.input = append(c.input, r)
+		}
+	}
+
+	switch {
+	case ui.RepeatPressed(ebiten.KeyBackspace):
+		if len(c.input) > 0 {
+			c.input = c.input[:len(c.input)-1]
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		c.submit()
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		line, matches := complete(string(c.input))
+		c.input = []rune(line)
+		if len(matches) > 0 {
+			c.Printf("%s", strings.Join(matches, "  "))
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		c.browse(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		c.browse(1)
+	case ui.RepeatPressed(ebiten.KeyPageUp):
+		c.scroll = min(c.scroll+5, max(0, len(c.lines)-1))
+	case ui.RepeatPressed(ebiten.KeyPageDown):
+		c.scroll = max(c.scroll-5, 0)
+	}
+	return true
+}
+
+// submit выполняет набранную строку и запоминает её в истории
+func (c *Console) submit() {
+	line := strings.TrimSpace(string(c.input))
+	c.input = c.input[:0]
+	c.draft = nil
+	if line == "" {
+		return
+	}
+	if len(c.history) == 0 || c.history[len(c.history)-1] != line {
+		c.history = append(c.history, line)
+		if len(c.history) > maxHistory {
+			c.history = c.history[1:]
+		}
+	}
+	c.historyPos = len(c.history)
+
+	c.Printf("> %s", line)
+	c.Exec(line)
+}
+
+// browse листает историю; step -1 - к более старым командам
+func (c *Console) browse(step int) {
+	pos := c.historyPos + step
+	if pos < 0 || pos > len(c.history) {
+		return
+	}
+	if c.historyPos == len(c.history) {
+		c.draft = append(c.draft[:0], c.input...)
+	}
+	c.historyPos = pos
+	if pos == len(c.history) {
+		c.input = append(c.input[:0], c.draft...)
+		return
+	}
+	c.input = []rune(c.history[pos])
+}
+
+// Draw отрисовывает консоль на верхней половине экрана
+func (c *Console) Draw(screen *ebiten.Image) {
+	if !c.Open {
+		return
+	}
+	width := float32(screen.Bounds().Dx())
+	height := float32(screen.Bounds().Dy() / 2)
+	vector.DrawFilledRect(screen, 0, 0, width, height, color.RGBA{0, 0, 0, 210}, false)
+	vector.DrawFilledRect(screen, 0, height, width, 2, color.RGBA{80, 200, 255, 255}, false)
+
+	style := text.Options{Font: text.Mono(), Size: text.SizeSmall}
+
+	// Строка ввода внизу, вывод над ней снизу вверх
+	inputY := float64(height) - lineHeight
+	prompt := "> " + string(c.input)
+	if c.blink/30%2 == 0 {
+		prompt += "_"
+	}
+	text.Draw(screen, prompt, 10, inputY, style)
+
+	y := inputY - lineHeight
+	for i := len(c.lines) - 1 - c.scroll; i >= 0 && y >= 0; i-- {
+		text.Draw(screen, c.lines[i], 10, y, style)
+		y -= lineHeight
+	}
+}
@@ -5,15 +5,24 @@ import (
     "github.com/hajimehoshi/ebiten/v2/ebitenutil"
     "image/color"
     "fmt"
+    "sort"
+    "superpupergame/atlas"
+    "superpupergame/text"
 )
 
+// atlasThumbSize - сторона миниатюры страницы атласа в отладочном просмотре
+const atlasThumbSize = 256
+
 // Debug содержит глобальные настройки отладки
 type Debug struct {
     Enabled        bool            // Флаг включения/выключения режима отладки
     ShowFPS        bool            // Показывать FPS
     ShowHitboxes   bool            // Показывать хитбоксы
     ShowPositions  bool            // Показывать координаты объектов
+    ShowAtlas      bool            // Показывать страницы атласа и их заполненность
+    ShowInspector  bool            // Выбирать объекты щелчком и показывать их свойства
     DebugMessages  []string        // Список отладочных сообщений
+    Errors         map[string]string // Ошибки перезагрузки ресурсов по имени файла
 }
 
 // NewDebug создает новый экземпляр Debug
@@ -24,6 +33,7 @@ func NewDebug() *Debug {
         ShowHitboxes:  true,
         ShowPositions: true,
         DebugMessages: make([]string, 0),
+        Errors:        make(map[string]string),
     }
 }
 
@@ -45,13 +55,13 @@ func (d *Debug) DrawDebugInfo(screen *ebiten.Image, tps float64) {
     
     // Показываем FPS если включено
     if d.ShowFPS {
-        ebitenutil.DebugPrintAt(screen, fmt.Sprintf("FPS: %.2f", ebiten.ActualFPS()), 10, 10)
-        ebitenutil.DebugPrintAt(screen, fmt.Sprintf("TPS: %.2f", tps), 10, 30)
+        text.Print(screen, fmt.Sprintf("FPS: %.2f", ebiten.ActualFPS()), 10, 10)
+        text.Print(screen, fmt.Sprintf("TPS: %.2f", tps), 10, 30)
     }
     
     // Показываем отладочные сообщения
     for i, msg := range d.DebugMessages {
-        ebitenutil.DebugPrintAt(screen, msg, 10, 50+i*20)
+        text.Print(screen, msg, 10, 50+i*20)
     }
 }
 
@@ -67,6 +77,66 @@ func (d *Debug) DrawHitbox(screen *ebiten.Image, x, y, width, height float64) {
     ebitenutil.DrawLine(screen, x, y+height, x, y, color.RGBA{255, 0, 0, 255})
 }
 
+// DrawAtlas отрисовывает миниатюры страниц атласа с количеством спрайтов и заполненностью
+func (d *Debug) DrawAtlas(screen *ebiten.Image, a *atlas.Atlas) {
+    if !d.Enabled || !d.ShowAtlas {
+        return
+    }
+    
+    screenWidth := screen.Bounds().Dx()
+    x, y := 10, screen.Bounds().Dy()-atlasThumbSize-40
+    for i, page := range a.Pages() {
+        size := page.Image.Bounds().Size()
+        scale := float64(atlasThumbSize) / float64(max(size.X, size.Y))
+        
+        // Переносим миниатюры на строку выше, если они не помещаются
+        if x+atlasThumbSize > screenWidth {
+            x = 10
+            y -= atlasThumbSize + 40
+        }
+        
+        // Тёмная подложка показывает границы страницы
+        ebitenutil.DrawRect(screen, float64(x), float64(y), float64(size.X)*scale, float64(size.Y)*scale, color.RGBA{0, 0, 0, 200})
+        op := &ebiten.DrawImageOptions{}
+        op.GeoM.Scale(scale, scale)
+        op.GeoM.Translate(float64(x), float64(y))
+        screen.DrawImage(page.Image, op)
+        
+        info := fmt.Sprintf("Стр. %d: %dx%d, спрайтов %d, %.1f%%", i, size.X, size.Y, page.Sprites, page.Occupancy()*100)
+        text.Print(screen, info, x, y+atlasThumbSize+5)
+        x += atlasThumbSize + 20
+    }
+}
+
+// SetError запоминает ошибку файла для показа поверх игры; nil убирает её
+func (d *Debug) SetError(source string, err error) {
+    if err == nil {
+        delete(d.Errors, source)
+        return
+    }
+    d.Errors[source] = err.Error()
+}
+
+// DrawErrors отрисовывает ошибки перезагрузки ресурсов.
+// Они видны и при выключенной отладке: появляются только в режиме разработки и их нельзя пропустить.
+func (d *Debug) DrawErrors(screen *ebiten.Image) {
+    if len(d.Errors) == 0 {
+        return
+    }
+    
+    sources := make([]string, 0, len(d.Errors))
+    for source := range d.Errors {
+        sources = append(sources, source)
+    }
+    sort.Strings(sources)
+    
+    y := screen.Bounds().Dy() - 20*len(sources) - 10
+    ebitenutil.DrawRect(screen, 0, float64(y-5), float64(screen.Bounds().Dx()), float64(20*len(sources)+10), color.RGBA{120, 0, 0, 220})
+    for i, source := range sources {
+        text.Print(screen, source+": "+d.Errors[source], 10, y+i*20)
+    }
+}
+
 // AddMessage добавляет отладочное сообщение
 func (d *Debug) AddMessage(msg string) {
     if !d.Enabled {
@@ -0,0 +1,79 @@
+package debug
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Property - свойство объекта, видимое в инспекторе
+type Property struct {
+	Name  string             // Имя свойства
+	Value func() string      // Текущее значение в виде строки
+	Set   func(string) error // Разбирает и устанавливает новое значение; nil - только для чтения
+}
+
+// Inspectable - объект мира, который можно выбрать щелчком и посмотреть в инспекторе
+type Inspectable interface {
+	// GetHitbox возвращает хитбокс объекта в координатах арены; по нему объект выбирается щелчком
+	GetHitbox() (x, y, width, height float64)
+
+	// InspectName возвращает вид объекта для заголовка инспектора
+	InspectName() string
+
+	// Inspect возвращает свойства объекта; порядок свойств - порядок строк в инспекторе
+	Inspect() []Property
+}
+
+// Float возвращает изменяемое свойство с дробным значением
+func Float(name string, v *float64) Property {
+	return Property{
+		Name:  name,
+		Value: func() string { return strconv.FormatFloat(*v, 'f', 2, 64) },
+		Set: func(s string) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				return fmt.Errorf("%q - не число", s)
+			}
+			*v = f
+			return nil
+		},
+	}
+}
+
+// Int возвращает изменяемое свойство с целым значением
+func Int(name string, v *int) Property {
+	return Property{
+		Name:  name,
+		Value: func() string { return strconv.Itoa(*v) },
+		Set: func(s string) error {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return fmt.Errorf("%q - не целое число", s)
+			}
+			*v = n
+			return nil
+		},
+	}
+}
+
+// Bool возвращает изменяемое свойство с логическим значением
+func Bool(name string, v *bool) Property {
+	return Property{
+		Name:  name,
+		Value: func() string { return strconv.FormatBool(*v) },
+		Set: func(s string) error {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return fmt.Errorf("%q - не true или false", s)
+			}
+			*v = b
+			return nil
+		},
+	}
+}
+
+// ReadOnly возвращает свойство только для чтения, значение которого вычисляется при показе
+func ReadOnly(name string, value func() string) Property {
+	return Property{Name: name, Value: value}
+}
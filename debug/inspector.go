@@ -0,0 +1,263 @@
+package debug
+
+import (
+	"image/color"
+	"slices"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/i18n"
+	"superpupergame/text"
+	"superpupergame/viewport"
+)
+
+// Размеры панели инспектора
+const (
+	inspectorX         = 10  // Левый край панели на экране
+	inspectorY         = 260 // Верхний край панели на экране, под отладочными сообщениями
+	inspectorWidth     = 340 // Ширина панели
+	inspectorRowHeight = 18  // Высота строки
+	inspectorPickSlop  = 4   // На сколько пикселей хитбокс расширяется при выборе щелчком
+)
+
+// Inspector - отладочный инспектор объектов: щелчок по объекту выбирает его,
+// панель показывает свойства объекта, позволяет менять их, замораживать и удалять объект
+type Inspector struct {
+	// Selected - выбранный объект; nil, если ничего не выбрано
+	Selected Inspectable
+
+	// OnDelete удаляет объект из мира; false - объект удалить нельзя
+	OnDelete func(Inspectable) bool
+
+	// frozen - замороженные объекты; их Update не вызывается
+	frozen map[Inspectable]bool
+
+	// row - выбранная строка свойств
+	row int
+
+	// editing - вводится ли новое значение свойства
+	editing bool
+
+	// input - вводимое значение
+	input []rune
+
+	// status - итог последнего действия или ошибка ввода
+	status string
+}
+
+// NewInspector создаёт инспектор без выбранного объекта
+func NewInspector() *Inspector {
+	return &Inspector{frozen: make(map[Inspectable]bool)}
+}
+
+// Frozen сообщает, заморожен ли объект
+func (in *Inspector) Frozen(e Inspectable) bool {
+	return in.frozen[e]
+}
+
+// Clear снимает выбор и размораживает все объекты
+func (in *Inspector) Clear() {
+	in.selectEntity(nil)
+	clear(in.frozen)
+}
+
+// selectEntity выбирает объект и начинает показ его свойств с первой строки
+func (in *Inspector) selectEntity(e Inspectable) {
+	in.Selected = e
+	in.row = 0
+	in.editing = false
+	in.status = ""
+}
+
+// Update обрабатывает щелчки и клавиши инспектора; entities - объекты мира в порядке отрисовки.
+// Возвращает true, пока вводится значение: клавиатура принадлежит инспектору, а мир стоит.
+func (in *Inspector) Update(entities []Inspectable) bool {
+	// Объекты, которых больше нет в мире, забываются
+	if in.Selected != nil && !slices.Contains(entities, in.Selected) {
+		in.selectEntity(nil)
+	}
+	for e := range in.frozen {
+		if !slices.Contains(entities, e) {
+			delete(in.frozen, e)
+		}
+	}
+
+	if in.editing {
+		in.updateEdit()
+		return true
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		in.click(entities)
+	}
+	if in.Selected == nil {
+		return false
+	}
+
+	props := in.Selected.Inspect()
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		in.row = max(in.row-1, 0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		in.row = min(in.row+1, len(props)-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		in.startEdit(props)
+	case inpututil.IsKeyJustPressed(ebiten.KeyF):
+		in.frozen[in.Selected] = !in.frozen[in.Selected]
+		if !in.frozen[in.Selected] {
+			delete(in.frozen, in.Selected)
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete):
+		if in.OnDelete == nil || !in.OnDelete(in.Selected) {
+			in.status = i18n.T("debug.inspector.undeletable")
+			break
+		}
+		delete(in.frozen, in.Selected)
+		in.selectEntity(nil)
+	}
+	return false
+}
+
+// click выбирает строку панели или объект под курсором; щелчок мимо объектов снимает выбор
+func (in *Inspector) click(entities []Inspectable) {
+	cx, cy := ebiten.CursorPosition()
+	if in.Selected != nil {
+		props := in.Selected.Inspect()
+		if row, ok := in.rowAt(float64(cx), float64(cy), len(props)); ok {
+			in.row = row
+			in.startEdit(props)
+			return
+		}
+		if in.panelContains(float64(cx), float64(cy), len(props)) {
+			return
+		}
+	}
+
+	// Сверху лежат объекты, нарисованные последними
+	x, y := viewport.CursorArena()
+	for _, e := range slices.Backward(entities) {
+		hx, hy, hw, hh := e.GetHitbox()
+		if x >= hx-inspectorPickSlop && x <= hx+hw+inspectorPickSlop &&
+			y >= hy-inspectorPickSlop && y <= hy+hh+inspectorPickSlop {
+			in.selectEntity(e)
+			return
+		}
+	}
+	in.selectEntity(nil)
+}
+
+// startEdit начинает ввод значения выбранной строки, если свойство можно менять
+func (in *Inspector) startEdit(props []Property) {
+	if in.row >= len(props) || props[in.row].Set == nil {
+		return
+	}
+	in.editing = true
+	in.input = []rune(props[in.row].Value())
+	in.status = ""
+}
+
+// updateEdit обрабатывает ввод значения: Enter применяет его, Escape отменяет
+func (in *Inspector) updateEdit() {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if unicode.IsPrint(r) {
+			in.input = append(in.input, r)
+		}
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		if len(in.input) > 0 {
+			in.input = in.input[:len(in.input)-1]
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		in.editing = false
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		in.editing = false
+		props := in.Selected.Inspect()
+		if in.row < len(props) {
+			if err := props[in.row].Set(string(in.input)); err != nil {
+				in.status = i18n.T("debug.inspector.invalid", err)
+			}
+		}
+	}
+}
+
+// rowTop возвращает верх строки свойства с номером i
+func rowTop(i int) float64 {
+	return inspectorY + float64(i+1)*inspectorRowHeight + 5
+}
+
+// panelHeight возвращает высоту панели с указанным числом свойств
+func panelHeight(rows int) float64 {
+	return float64(rows+3)*inspectorRowHeight + 10
+}
+
+// rowAt возвращает номер строки свойства под точкой экрана
+func (in *Inspector) rowAt(x, y float64, rows int) (int, bool) {
+	if x < inspectorX || x > inspectorX+inspectorWidth || y < rowTop(0) {
+		return 0, false
+	}
+	row := int((y - rowTop(0)) / inspectorRowHeight)
+	return row, row < rows
+}
+
+// panelContains сообщает, лежит ли точка экрана на панели
+func (in *Inspector) panelContains(x, y float64, rows int) bool {
+	return x >= inspectorX && x <= inspectorX+inspectorWidth &&
+		y >= inspectorY && y <= inspectorY+panelHeight(rows)
+}
+
+// DrawWorld обводит выбранный и замороженные объекты; рисуется в координатах арены
+func (in *Inspector) DrawWorld(screen *ebiten.Image) {
+	for e := range in.frozen {
+		x, y, w, h := e.GetHitbox()
+		vector.StrokeRect(screen, float32(x-2), float32(y-2), float32(w+4), float32(h+4), 2, color.RGBA{80, 200, 255, 255}, false)
+	}
+	if in.Selected != nil {
+		x, y, w, h := in.Selected.GetHitbox()
+		vector.StrokeRect(screen, float32(x-4), float32(y-4), float32(w+8), float32(h+8), 2, color.RGBA{255, 220, 0, 255}, false)
+	}
+}
+
+// Draw отрисовывает панель со свойствами выбранного объекта в экранных координатах
+func (in *Inspector) Draw(screen *ebiten.Image) {
+	style := text.Options{Font: text.Mono(), Size: text.SizeSmall}
+	if in.Selected == nil {
+		text.Draw(screen, i18n.T("debug.inspector.empty"), inspectorX, inspectorY, style)
+		return
+	}
+
+	props := in.Selected.Inspect()
+	vector.DrawFilledRect(screen, inspectorX, inspectorY, inspectorWidth, float32(panelHeight(len(props))), color.RGBA{0, 0, 0, 200}, false)
+
+	title := in.Selected.InspectName()
+	if in.frozen[in.Selected] {
+		title += " " + i18n.T("debug.inspector.frozen")
+	}
+	text.Draw(screen, title, inspectorX+10, inspectorY+5, text.Options{Font: text.Mono(), Size: text.SizeSmall, Color: color.RGBA{255, 220, 0, 255}})
+
+	for i, prop := range props {
+		y := rowTop(i)
+		if i == in.row {
+			vector.DrawFilledRect(screen, inspectorX, float32(y), inspectorWidth, inspectorRowHeight, color.RGBA{255, 255, 255, 40}, false)
+		}
+		value := prop.Value()
+		if i == in.row && in.editing {
+			value = string(in.input) + "_"
+		}
+		row := style
+		if prop.Set == nil {
+			row.Color = color.RGBA{160, 160, 160, 255}
+		}
+		text.Draw(screen, prop.Name, inspectorX+10, y, row)
+		text.Draw(screen, value, inspectorX+170, y, row)
+	}
+
+	hint := i18n.T("debug.inspector.hint")
+	if in.status != "" {
+		hint = in.status
+	}
+	text.Draw(screen, hint, inspectorX+10, rowTop(len(props))+5, style)
+}
@@ -1,53 +1,67 @@
 package enemy
 
 import (
+	"fmt"
 	"math"
-	"math/rand"
-	"time"
+	"math/rand/v2"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"image/color"
+	"superpupergame/animation"
+	"superpupergame/debug"
 	"superpupergame/utils"
+	"superpupergame/viewport"
 )
 
+// spriteOffset - насколько кадр врага (24x24) больше его хитбокса (20x20) с каждой стороны
+const spriteOffset = 2
+
 type Enemy struct {
-	X, Y   float64
-	Speed  float64
-	Alive  bool
+	X, Y     float64
+	VX, VY   float64 // Смещение за последний тик
+	Speed    float64
+	Alive    bool
+	Animator *animation.Animator // Клипы "move" и "death"
 }
 
-func NewEnemy(x, y float64) *Enemy {
+// NewEnemy создаёт врага в указанной позиции; sheet - лист с клипами "move" и "death"
+func NewEnemy(x, y float64, sheet *animation.Sheet) *Enemy {
 	return &Enemy{
-		X:     x,
-		Y:     y,
-		Speed: 3.0,
-		Alive: true,
+		X:        x,
+		Y:        y,
+		Speed:    3.0,
+		Alive:    true,
+		Animator: animation.NewAnimator(sheet, "move"),
 	}
 }
 
-// NewRandomEdgeEnemy создаёт врага на случайном краю экрана
-func NewRandomEdgeEnemy() *Enemy {
-	rand.Seed(time.Now().UnixNano()) // Инициализируем генератор случайных чисел
-	edge := rand.Intn(4)             // 0: верх, 1: право, 2: низ, 3: лево
+// NewRandomEdgeEnemy создаёт врага на случайном краю экрана,
+// используя генератор случайных чисел забега
+func NewRandomEdgeEnemy(rng *rand.Rand, sheet *animation.Sheet) *Enemy {
+	edge := rng.IntN(4) // 0: верх, 1: право, 2: низ, 3: лево
 	switch edge {
 	case 0: // Верх
-		return NewEnemy(float64(rand.Intn(1280)), 0)
+		return NewEnemy(float64(rng.IntN(viewport.ArenaWidth)), 0, sheet)
 	case 1: // Право
-		return NewEnemy(1260, float64(rand.Intn(960)))
+		return NewEnemy(viewport.ArenaWidth-20, float64(rng.IntN(viewport.ArenaHeight)), sheet)
 	case 2: // Низ
-		return NewEnemy(float64(rand.Intn(1280)), 940)
+		return NewEnemy(float64(rng.IntN(viewport.ArenaWidth)), viewport.ArenaHeight-20, sheet)
 	case 3: // Лево
-		return NewEnemy(0, float64(rand.Intn(960)))
+		return NewEnemy(0, float64(rng.IntN(viewport.ArenaHeight)), sheet)
 	default:
-		return NewEnemy(0, 0) // На всякий случай
+		return NewEnemy(0, 0, sheet) // На всякий случай
 	}
 }
 
 func (e *Enemy) Update(targetX, targetY float64) {
+	// Убитый враг только доигрывает анимацию смерти
 	if !e.Alive {
+		e.Animator.Play("death")
+		e.Animator.Update()
 		return
 	}
+	e.Animator.Update()
+
+	x, y := e.X, e.Y
 	dx := targetX - e.X
 	dy := targetY - e.Y
 	distance := math.Sqrt(dx*dx + dy*dy)
@@ -56,16 +70,37 @@ func (e *Enemy) Update(targetX, targetY float64) {
 		e.Y += (dy / distance) * e.Speed
 	}
 
-	e.X = utils.Clamp(e.X, 0, 1260)
-	e.Y = utils.Clamp(e.Y, 0, 940)
+	e.X = utils.Clamp(e.X, 0, viewport.ArenaWidth-20)
+	e.Y = utils.Clamp(e.Y, 0, viewport.ArenaHeight-20)
+	e.VX, e.VY = e.X-x, e.Y-y
 }
 
 func (e *Enemy) Draw(screen *ebiten.Image) {
-	if e.Alive {
-		ebitenutil.DrawRect(screen, e.X, e.Y, 20, 20, color.RGBA{255, 0, 0, 255})
+	// После анимации смерти врага больше не видно
+	if !e.Alive && e.Animator.Finished() {
+		return
 	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(e.X-spriteOffset, e.Y-spriteOffset)
+	screen.DrawImage(e.Animator.Image(), op)
 }
 
 func (e *Enemy) GetHitbox() (x, y, width, height float64) {
     return e.X, e.Y, 20.0, 20.0 // Размеры врага
-}
\ No newline at end of file
+}
+// InspectName возвращает вид объекта для отладочного инспектора
+func (e *Enemy) InspectName() string {
+	return "enemy"
+}
+
+// Inspect возвращает свойства врага для отладочного инспектора
+func (e *Enemy) Inspect() []debug.Property {
+	return []debug.Property{
+		debug.ReadOnly("animation", e.Animator.ClipName),
+		debug.Float("x", &e.X),
+		debug.Float("y", &e.Y),
+		debug.ReadOnly("velocity", func() string { return fmt.Sprintf("%.2f, %.2f", e.VX, e.VY) }),
+		debug.Float("speed", &e.Speed),
+		debug.Bool("alive", &e.Alive),
+	}
+}
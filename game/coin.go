@@ -2,82 +2,55 @@ package game
 
 import (
     "github.com/hajimehoshi/ebiten/v2"
-    "github.com/hajimehoshi/ebiten/v2/ebitenutil"
-    "image"
-    "log"
-    "math/rand"
-    "time"
+    "math/rand/v2"
+    "strconv"
+    "superpupergame/animation"
+    "superpupergame/debug"
 )
 
 // Coin представляет монетку в игре
 type Coin struct {
     x, y         float64
-    spriteSheet  *ebiten.Image
     frameWidth   int
     frameHeight  int
-    frameCount   int
-    currentFrame int
-    animSpeed    float64
-    lastUpdated  time.Time
+    animator     *animation.Animator // Вращение монетки (клип "spin")
 }
 
 // NewCoin создаёт новую монетку с случайной позицией
-func NewCoin(screenWidth, screenHeight float64) *Coin {
-    img, _, err := ebitenutil.NewImageFromFile("assets/coin.png")
-    if err != nil {
-        log.Fatal("Ошибка загрузки изображения монетки:", err)
-    }
-    
-    // Получаем размер изображения
-    width, height := img.Size()
+func NewCoin(screenWidth, screenHeight float64, rng *rand.Rand, sheet *animation.Sheet) *Coin {
+    return NewCoinAt(rng.Float64()*screenWidth, rng.Float64()*screenHeight, sheet)
+}
+
+// NewCoinAt создаёт новую монетку в указанной позиции; sheet - лист с клипом "spin"
+func NewCoinAt(x, y float64, sheet *animation.Sheet) *Coin {
+    animator := animation.NewAnimator(sheet, "spin")
     
-    // Определяем количество кадров в спрайт-листе
-    // Предполагаем, что спрайты расположены в одну линию горизонтально
-    frameCount := 15 // Предполагаемое количество кадров, измените если необходимо
-    frameWidth := width / frameCount
+    // Размер монетки берём из первого кадра клипа
+    size := animator.Image().Bounds().Size()
     
     return &Coin{
-        x:           rand.Float64() * screenWidth,
-        y:           rand.Float64() * screenHeight,
-        spriteSheet: img,
-        frameWidth:  frameWidth,
-        frameHeight: height,
-        frameCount:  frameCount,
-        currentFrame: 0,
-        animSpeed:   0.15, // Скорость анимации - количество секунд между кадрами
-        lastUpdated: time.Now(),
+        x:           x,
+        y:           y,
+        frameWidth:  size.X,
+        frameHeight: size.Y,
+        animator:    animator,
     }
 }
 
 // Update обновляет состояние монетки, включая анимацию
 func (c *Coin) Update() {
-    now := time.Now()
-    
-    // Обновляем анимацию по таймеру
-    if now.Sub(c.lastUpdated).Seconds() >= c.animSpeed {
-        c.currentFrame = (c.currentFrame + 1) % c.frameCount
-        c.lastUpdated = now
-    }
+    c.animator.Update()
 }
 
 // Draw отрисовывает текущий кадр монетки на экране
 func (c *Coin) Draw(screen *ebiten.Image) {
     op := &ebiten.DrawImageOptions{}
     
-    // Вычисляем позицию текущего кадра в спрайт-листе
-    sx := c.frameWidth * c.currentFrame
-    sy := 0
-    
-    // Вырезаем текущий кадр из спрайт-листа
-    // Используем image.Rect вместо ebiten.Rect
-    r := image.Rect(sx, sy, sx+c.frameWidth, sy+c.frameHeight)
-    subImage := c.spriteSheet.SubImage(r).(*ebiten.Image)
-    
     // Позиционируем кадр в игровом мире
     op.GeoM.Translate(c.x, c.y)
     
     // Отрисовываем текущий кадр
-    screen.DrawImage(subImage, op)
+    screen.DrawImage(c.animator.Image(), op)
 }
 
 // Collides проверяет, пересекается ли монетка с заданным прямоугольником
@@ -96,6 +69,29 @@ func (c *Coin) GetY() float64 {
     return c.y
 }
 
+// Frame возвращает текущий кадр анимации монетки
+func (c *Coin) Frame() int {
+    return c.animator.Frame()
+}
+
+// SetFrame устанавливает текущий кадр анимации монетки
+func (c *Coin) SetFrame(frame int) {
+    c.animator.SetFrame(frame)
+}
+
 func (c *Coin) GetHitbox() (x, y, width, height float64) {
     return c.GetX(), c.GetY(), 16.0, 16.0 // Предполагаемые размеры монетки
-}
\ No newline at end of file
+}
+// InspectName возвращает вид объекта для отладочного инспектора
+func (c *Coin) InspectName() string {
+    return "coin"
+}
+
+// Inspect возвращает свойства монетки для отладочного инспектора
+func (c *Coin) Inspect() []debug.Property {
+    return []debug.Property{
+        debug.Float("x", &c.x),
+        debug.Float("y", &c.y),
+        debug.ReadOnly("frame", func() string { return strconv.Itoa(c.Frame()) }),
+    }
+}
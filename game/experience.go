@@ -0,0 +1,148 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/debug"
+)
+
+// Параметры сферы опыта
+const (
+	orbRadius       = 5.0   // Радиус сферы опыта
+	orbMagnetRadius = 120.0 // Расстояние, с которого сфера притягивается к игроку
+	orbMagnetSpeed  = 4.0   // Скорость притяжения сферы к игроку
+)
+
+// XPOrb представляет сферу опыта, выпадающую из врагов
+type XPOrb struct {
+	x, y  float64 // Позиция центра сферы
+	Value int     // Количество опыта, которое даёт сфера
+	pulse float64 // Фаза пульсации для анимации
+}
+
+// NewXPOrb создаёт новую сферу опыта в указанной позиции
+func NewXPOrb(x, y float64, value int) *XPOrb {
+	return &XPOrb{
+		x:     x,
+		y:     y,
+		Value: value,
+	}
+}
+
+// Update обновляет анимацию сферы и притягивает её к цели, если та достаточно близко
+func (o *XPOrb) Update(targetX, targetY float64) {
+	o.pulse += 1.0 / 60.0
+
+	dx := targetX - o.x
+	dy := targetY - o.y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance > 0 && distance < orbMagnetRadius {
+		o.x += dx / distance * orbMagnetSpeed
+		o.y += dy / distance * orbMagnetSpeed
+	}
+}
+
+// Draw отрисовывает сферу опыта
+func (o *XPOrb) Draw(screen *ebiten.Image) {
+	radius := orbRadius + math.Sin(o.pulse*6)*1.0
+	vector.DrawFilledCircle(screen, float32(o.x), float32(o.y), float32(radius), color.RGBA{80, 200, 255, 255}, true)
+}
+
+// Collides проверяет, пересекается ли сфера с заданным прямоугольником
+func (o *XPOrb) Collides(x, y float64, width, height int) bool {
+	ox, oy, ow, oh := o.GetHitbox()
+	return x < ox+ow &&
+		x+float64(width) > ox &&
+		y < oy+oh &&
+		y+float64(height) > oy
+}
+
+// GetX возвращает координату X центра сферы
+func (o *XPOrb) GetX() float64 {
+	return o.x
+}
+
+// GetY возвращает координату Y центра сферы
+func (o *XPOrb) GetY() float64 {
+	return o.y
+}
+
+// GetHitbox возвращает координаты и размеры хитбокса сферы
+func (o *XPOrb) GetHitbox() (x, y, width, height float64) {
+	return o.x - orbRadius, o.y - orbRadius, orbRadius * 2, orbRadius * 2
+}
+
+// InspectName возвращает вид объекта для отладочного инспектора
+func (o *XPOrb) InspectName() string {
+	return "xp_orb"
+}
+
+// Inspect возвращает свойства сферы опыта для отладочного инспектора
+func (o *XPOrb) Inspect() []debug.Property {
+	return []debug.Property{
+		debug.Float("x", &o.x),
+		debug.Float("y", &o.y),
+		debug.Int("value", &o.Value),
+		debug.Float("pulse", &o.pulse),
+	}
+}
+
+// LevelCurve описывает, сколько опыта нужно для перехода на следующий уровень.
+// Порог для уровня n равен Base * n^Exponent.
+type LevelCurve struct {
+	Base     float64 `json:"base"`     // Опыт, необходимый для перехода с первого уровня
+	Exponent float64 `json:"exponent"` // Показатель роста порога с каждым уровнем
+}
+
+// DefaultLevelCurve - кривая уровней по умолчанию
+var DefaultLevelCurve = LevelCurve{Base: 50, Exponent: 1.4}
+
+// Threshold возвращает количество опыта, нужное для перехода с уровня level на следующий.
+// Порог не бывает меньше 1, иначе Experience.Add повышал бы уровень бесконечно.
+func (c LevelCurve) Threshold(level int) int {
+	if level < 1 {
+		level = 1
+	}
+	return max(1, int(math.Round(c.Base*math.Pow(float64(level), c.Exponent))))
+}
+
+// Experience хранит текущий уровень и накопленный опыт
+type Experience struct {
+	Level int        // Текущий уровень
+	XP    int        // Опыт, накопленный на текущем уровне
+	Curve LevelCurve // Кривая порогов уровней
+}
+
+// NewExperience создаёт прогресс первого уровня с указанной кривой
+func NewExperience(curve LevelCurve) *Experience {
+	return &Experience{
+		Level: 1,
+		Curve: curve,
+	}
+}
+
+// Reset сбрасывает прогресс на первый уровень
+func (e *Experience) Reset() {
+	e.Level = 1
+	e.XP = 0
+}
+
+// Next возвращает количество опыта, нужное для следующего уровня
+func (e *Experience) Next() int {
+	return e.Curve.Threshold(e.Level)
+}
+
+// Add добавляет опыт и возвращает количество полученных уровней
+func (e *Experience) Add(amount int) int {
+	gained := 0
+	e.XP += amount
+	for e.XP >= e.Next() {
+		e.XP -= e.Next()
+		e.Level++
+		gained++
+	}
+	return gained
+}
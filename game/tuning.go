@@ -0,0 +1,89 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EnemyTuning - параметры врага
+type EnemyTuning struct {
+	Speed      float64 `json:"speed"`       // Скорость движения (пикселей за кадр)
+	Score      int     `json:"score"`       // Очки за убийство
+	XP         int     `json:"xp"`          // Опыт в сфере, которая остаётся после врага
+	CoinChance float64 `json:"coin_chance"` // Вероятность выпадения дополнительной монетки (0..1)
+}
+
+// WaveTuning - параметры волн врагов
+type WaveTuning struct {
+	FirstEnemies   int     `json:"first_enemies"`    // Врагов в первой волне
+	EnemiesPerWave int     `json:"enemies_per_wave"` // Насколько больше врагов в каждой следующей волне
+	Heal           float64 `json:"heal"`             // Здоровье, восстанавливаемое за зачищенную волну
+}
+
+// CoinTuning - параметры монеток
+type CoinTuning struct {
+	Score        int     `json:"score"`         // Очки за монетку
+	Start        int     `json:"start"`         // Монеток в начале забега
+	Max          int     `json:"max"`           // Максимум монеток на экране
+	RespawnDelay float64 `json:"respawn_delay"` // Задержка перед появлением новой монетки после сбора (в секундах)
+}
+
+// Tuning - игровые числа, которые настраиваются без пересборки (assets/tuning.json)
+type Tuning struct {
+	Enemy EnemyTuning `json:"enemy"`
+	Waves WaveTuning  `json:"waves"`
+	Coins CoinTuning  `json:"coins"`
+	Level LevelCurve  `json:"level"`
+}
+
+// DefaultTuning возвращает значения, с которыми игра работает без файла настройки
+func DefaultTuning() *Tuning {
+	return &Tuning{
+		Enemy: EnemyTuning{Speed: 3.0, Score: 100, XP: 10, CoinChance: 0.3},
+		Waves: WaveTuning{FirstEnemies: 1, EnemiesPerWave: 1, Heal: 10},
+		Coins: CoinTuning{Score: 50, Start: 3, Max: 5, RespawnDelay: 2.0},
+		Level: DefaultLevelCurve,
+	}
+}
+
+// ParseTuning разбирает настройку из JSON; отсутствующие поля берутся из DefaultTuning
+func ParseTuning(data []byte) (*Tuning, error) {
+	tuning := DefaultTuning()
+	if err := json.Unmarshal(data, tuning); err != nil {
+		return nil, fmt.Errorf("повреждённая настройка игры: %w", err)
+	}
+	switch {
+	case tuning.Enemy.Speed <= 0:
+		return nil, fmt.Errorf("enemy.speed должна быть больше нуля")
+	case tuning.Enemy.Score < 0:
+		return nil, fmt.Errorf("enemy.score не может быть отрицательным")
+	case tuning.Enemy.XP < 0:
+		return nil, fmt.Errorf("enemy.xp не может быть отрицательным")
+	case tuning.Enemy.CoinChance < 0 || tuning.Enemy.CoinChance > 1:
+		return nil, fmt.Errorf("enemy.coin_chance должна быть от 0 до 1")
+	case tuning.Waves.FirstEnemies < 1:
+		return nil, fmt.Errorf("waves.first_enemies должно быть не меньше 1")
+	case tuning.Waves.EnemiesPerWave < 0:
+		return nil, fmt.Errorf("waves.enemies_per_wave не может быть отрицательным")
+	case tuning.Waves.Heal < 0:
+		return nil, fmt.Errorf("waves.heal не может быть отрицательным")
+	case tuning.Coins.Score < 0:
+		return nil, fmt.Errorf("coins.score не может быть отрицательным")
+	case tuning.Coins.Max < 0 || tuning.Coins.Start < 0:
+		return nil, fmt.Errorf("количество монеток не может быть отрицательным")
+	case tuning.Coins.Start > tuning.Coins.Max:
+		return nil, fmt.Errorf("coins.start не может быть больше coins.max")
+	case tuning.Coins.RespawnDelay < 0:
+		return nil, fmt.Errorf("coins.respawn_delay не может быть отрицательной")
+	case tuning.Level.Base <= 0:
+		return nil, fmt.Errorf("level.base должен быть больше нуля")
+	case tuning.Level.Exponent < 0:
+		return nil, fmt.Errorf("level.exponent не может быть отрицательным")
+	}
+	return tuning, nil
+}
+
+// WaveEnemies возвращает количество врагов в волне с номером wave (с единицы)
+func (t *Tuning) WaveEnemies(wave int) int {
+	return t.Waves.FirstEnemies + (wave-1)*t.Waves.EnemiesPerWave
+}
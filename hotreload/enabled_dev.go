@@ -0,0 +1,6 @@
+//go:build dev
+
+package hotreload
+
+// Enabled - горячая перезагрузка собрана в игру (сборка с -tags dev)
+const Enabled = true
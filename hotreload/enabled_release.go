@@ -0,0 +1,6 @@
+//go:build !dev
+
+package hotreload
+
+// Enabled - в релизной сборке горячая перезагрузка отключена; для разработки соберите игру с -tags dev
+const Enabled = false
@@ -0,0 +1,93 @@
+// Пакет hotreload следит за файлами ресурсов во время разработки
+// и сообщает об изменениях, чтобы игра перечитала их без перезапуска
+package hotreload
+
+import (
+	"io/fs"
+	"log"
+	"path/filepath"
+	"time"
+)
+
+// DefaultInterval - через сколько кадров каталоги проверяются снова (полсекунды при 60 TPS)
+const DefaultInterval = 30
+
+// stamp - признаки, по которым замечается изменение файла
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher опрашивает каталоги и вызывает OnChange для изменённых файлов.
+// Опрос вместо системных уведомлений работает одинаково на всех платформах
+// и не требует зависимостей.
+type Watcher struct {
+	// Dir - каталог, за которым ведётся наблюдение
+	Dir string
+
+	// Interval - период опроса (в кадрах)
+	Interval int
+
+	// OnChange вызывается с путём файла относительно Dir через "/"
+	OnChange func(name string)
+
+	// files - последние известные признаки файлов
+	files map[string]stamp
+
+	// ticks - кадры, прошедшие с последнего опроса
+	ticks int
+}
+
+// NewWatcher создаёт наблюдатель и запоминает текущее состояние каталога,
+// чтобы уже существующие файлы не считались изменёнными
+func NewWatcher(dir string, onChange func(name string)) *Watcher {
+	w := &Watcher{
+		Dir:      dir,
+		Interval: DefaultInterval,
+		OnChange: onChange,
+	}
+	w.files = w.scan()
+	return w
+}
+
+// Update вызывается каждый кадр и раз в Interval кадров проверяет каталог
+func (w *Watcher) Update() {
+	w.ticks++
+	if w.ticks < w.Interval {
+		return
+	}
+	w.ticks = 0
+
+	current := w.scan()
+	for name, s := range current {
+		if old, ok := w.files[name]; !ok || !old.modTime.Equal(s.modTime) || old.size != s.size {
+			w.OnChange(name)
+		}
+	}
+	w.files = current
+}
+
+// scan обходит каталог и собирает признаки всех файлов
+func (w *Watcher) scan() map[string]stamp {
+	files := make(map[string]stamp)
+	err := filepath.WalkDir(w.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// Файл могли удалить между чтением каталога и запросом сведений
+			return nil
+		}
+		rel, err := filepath.Rel(w.Dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = stamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Ошибка обхода каталога %s: %v", w.Dir, err)
+	}
+	return files
+}
@@ -0,0 +1,268 @@
+// Пакет i18n переводит строки интерфейса по ключам из таблиц локалей (assets/locales/*.json)
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale - локаль, из которой берутся строки, отсутствующие в текущей
+const DefaultLocale = "en"
+
+// NameKey - ключ, под которым локаль хранит своё название, например "Русский"
+const NameKey = "language.name"
+
+// Form - форма множественного числа
+type Form string
+
+const (
+	One   Form = "one"   // 1 враг, 21 враг
+	Few   Form = "few"   // 2 врага, 24 врага
+	Many  Form = "many"  // 5 врагов, 11 врагов
+	Other Form = "other" // Всё остальное; в английском - любое число кроме 1
+)
+
+// PluralRule выбирает форму множественного числа для n
+type PluralRule func(n int) Form
+
+// pluralRules - правила множественного числа по коду локали
+var pluralRules = map[string]PluralRule{
+	"en": englishPlural,
+	"ru": russianPlural,
+}
+
+// pluralForms - формы, которые должны быть у строки во множественном числе
+var pluralForms = map[string][]Form{
+	"en": {One, Other},
+	"ru": {One, Few, Many},
+}
+
+// englishPlural - правило английского языка: one для 1, other для остальных
+func englishPlural(n int) Form {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+// russianPlural - правило русского языка: одна монета, две монеты, пять монет
+func russianPlural(n int) Form {
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return One
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+// entry - строка перевода: простая или с формами множественного числа
+type entry struct {
+	text  string
+	forms map[Form]string
+}
+
+// UnmarshalJSON принимает строку или объект {"one": ..., "few": ..., "many": ...}
+func (e *entry) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &e.forms)
+}
+
+// Locale - таблица строк одного языка
+type Locale struct {
+	// Code - код языка, например "ru"
+	Code string
+
+	// entries - строки по ключу
+	entries map[string]entry
+
+	// plural - правило множественного числа
+	plural PluralRule
+}
+
+// ParseLocale разбирает таблицу строк вида {"ключ": "строка", "ключ": {"one": ..., "many": ...}}
+func ParseLocale(code string, data []byte) (*Locale, error) {
+	entries := make(map[string]entry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("повреждённая локаль %s: %w", code, err)
+	}
+	plural, ok := pluralRules[code]
+	if !ok {
+		plural = englishPlural
+	}
+	return &Locale{Code: code, entries: entries, plural: plural}, nil
+}
+
+// Name возвращает название языка на нём самом
+func (l *Locale) Name() string {
+	if e, ok := l.entries[NameKey]; ok && e.text != "" {
+		return e.text
+	}
+	return l.Code
+}
+
+// lookup возвращает строку по ключу; для строки с формами берётся форма для n
+func (l *Locale) lookup(key string, n int) (string, bool) {
+	e, ok := l.entries[key]
+	if !ok {
+		return "", false
+	}
+	if e.forms == nil {
+		return e.text, true
+	}
+	if s, ok := e.forms[l.plural(n)]; ok {
+		return s, true
+	}
+	s, ok := e.forms[Other]
+	return s, ok
+}
+
+// Catalog - набор локалей с текущей и резервной
+type Catalog struct {
+	// locales - локали по коду
+	locales map[string]*Locale
+
+	// current - код текущей локали
+	current string
+}
+
+// NewCatalog создаёт пустой каталог с текущей локалью DefaultLocale
+func NewCatalog() *Catalog {
+	return &Catalog{
+		locales: make(map[string]*Locale),
+		current: DefaultLocale,
+	}
+}
+
+// Add добавляет или заменяет локаль
+func (c *Catalog) Add(l *Locale) {
+	c.locales[l.Code] = l
+}
+
+// SetLocale переключает язык; строки берутся из новой локали со следующего кадра
+func (c *Catalog) SetLocale(code string) error {
+	if _, ok := c.locales[code]; !ok {
+		return fmt.Errorf("локаль %q не загружена", code)
+	}
+	c.current = code
+	return nil
+}
+
+// Locale возвращает код текущей локали
+func (c *Catalog) Locale() string {
+	return c.current
+}
+
+// Name возвращает название языка с указанным кодом на нём самом
+func (c *Catalog) Name(code string) string {
+	if l, ok := c.locales[code]; ok {
+		return l.Name()
+	}
+	return code
+}
+
+// T возвращает строку по ключу, подставляя аргументы через fmt.Sprintf.
+// Строка ищется в текущей локали, затем в DefaultLocale; если её нет нигде, возвращается сам ключ.
+func (c *Catalog) T(key string, args ...any) string {
+	return c.format(key, 1, args)
+}
+
+// N возвращает строку по ключу в форме множественного числа для n.
+// Без аргументов в строку подставляется само n.
+func (c *Catalog) N(key string, n int, args ...any) string {
+	if len(args) == 0 {
+		args = []any{n}
+	}
+	return c.format(key, n, args)
+}
+
+// format находит строку с учётом резервной локали и подставляет аргументы
+func (c *Catalog) format(key string, n int, args []any) string {
+	s, ok := "", false
+	if l, found := c.locales[c.current]; found {
+		s, ok = l.lookup(key, n)
+	}
+	if !ok {
+		if l, found := c.locales[DefaultLocale]; found {
+			s, ok = l.lookup(key, n)
+		}
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(s, args...)
+	}
+	return s
+}
+
+// Missing возвращает отсутствующие строки: ключи, которые есть в одной локали, но не в другой,
+// и строки во множественном числе без нужных форм. Каждая строка отчёта - "локаль: ключ".
+func (c *Catalog) Missing() []string {
+	keys := make(map[string]bool)
+	for _, l := range c.locales {
+		for key := range l.entries {
+			keys[key] = true
+		}
+	}
+
+	var missing []string
+	for code, l := range c.locales {
+		for key := range keys {
+			e, ok := l.entries[key]
+			if !ok {
+				missing = append(missing, code+": "+key)
+				continue
+			}
+			if e.forms == nil {
+				continue
+			}
+			forms, ok := pluralForms[code]
+			if !ok {
+				forms = []Form{Other}
+			}
+			var absent []string
+			for _, form := range forms {
+				if _, ok := e.forms[form]; !ok {
+					absent = append(absent, string(form))
+				}
+			}
+			if len(absent) > 0 {
+				missing = append(missing, fmt.Sprintf("%s: %s (%s)", code, key, strings.Join(absent, ", ")))
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// catalog - каталог, через который переводит весь интерфейс
+var catalog = NewCatalog()
+
+// SetCatalog задаёт каталог, через который переводит весь интерфейс
+func SetCatalog(c *Catalog) {
+	catalog = c
+}
+
+// Default возвращает каталог интерфейса
+func Default() *Catalog {
+	return catalog
+}
+
+// T переводит строку через каталог интерфейса
+func T(key string, args ...any) string {
+	return catalog.T(key, args...)
+}
+
+// N переводит строку во множественном числе через каталог интерфейса
+func N(key string, n int, args ...any) string {
+	return catalog.N(key, n, args...)
+}
@@ -1,13 +1,33 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"superpupergame/assets"
+	"superpupergame/camera"
+	"superpupergame/config"
+	"superpupergame/console"
 	"superpupergame/debug" // Новый импорт для пакета отладки
+	"superpupergame/game"
+	"superpupergame/hotreload"
+	"superpupergame/i18n"
+	"superpupergame/particles"
 	"superpupergame/player" // Импортируем пакет player
+	"superpupergame/profile"
+	"superpupergame/profiler"
+	"superpupergame/sound"
 	"superpupergame/states"
+	"superpupergame/text"
+	"superpupergame/ui"
+	"superpupergame/viewport"
 )
 
 // Game представляет главную структуру игры, реализующую интерфейс ebiten.Game
@@ -15,43 +35,238 @@ type Game struct {
 	stateMachine *states.StateMachine
 	player       *player.Player // Добавляем поле для игрока
 	debugSystem  *debug.Debug   // Добавляем поле для системы отладки
+	playState    *states.PlayState // Игровое состояние, сохраняемое при закрытии окна
+	settings     *config.Settings  // Настройки игры, сохраняемые при закрытии окна
+	sound        *sound.Manager    // Звуковые эффекты и музыка
+	assets       *assets.Manager   // Ресурсы и атлас спрайтов
+	watcher      *hotreload.Watcher // Наблюдатель за файлами ресурсов; nil, если горячая перезагрузка выключена
+	console      *console.Console   // Консоль разработчика; пока она открыта, игра стоит
 }
 
-// NewGame создает новый экземпляр игры
-func NewGame() *Game {
+// NewGame создает новый экземпляр игры с указанными настройками
+func NewGame(settings *config.Settings, assetManager *assets.Manager) *Game {
 
-	// Создаем систему отладки
+	// Создаем систему отладки с сохранёнными значениями по умолчанию
 	debugSystem := debug.NewDebug()
+	debugSystem.Enabled = settings.Debug.Enabled
+	debugSystem.ShowFPS = settings.Debug.ShowFPS
+	debugSystem.ShowHitboxes = settings.Debug.ShowHitboxes
+	debugSystem.ShowPositions = settings.Debug.ShowPositions
 
-	gamePlayer := player.NewPlayer(640, 480, debugSystem)
+	// Шрифты интерфейса; Go Regular и Go Mono содержат кириллицу
+	text.SetDefault(loadFont(assetManager, assets.DefaultFont))
+	text.SetMono(loadFont(assetManager, assets.MonoFont))
+	
+	// Готовый атлас необязателен; без него спрайты упаковываются при загрузке
+	if err := assetManager.LoadAtlas("atlas.json"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Ошибка загрузки атласа: %v", err)
+	}
+	
+	gamePlayer := player.NewPlayer(viewport.ArenaWidth/2, viewport.ArenaHeight/2, debugSystem, &settings.Keys, assetManager)
+	
+	// Загружаем профиль игрока с рекордами и статистикой
+	gameProfile := profile.Load()
+	
+	// Создаем звуковую систему и загружаем звуки
+	soundManager := sound.NewManager(settings)
+	soundManager.ReadFile = assetManager.ReadFile
+	soundManager.Dir = "audio"
+	soundManager.LoadDefaults()
+	
+	// Загружаем пресеты частиц; без них игра работает, но без эффектов
+	particlePresets, err := loadParticlePresets(assetManager)
+	if err != nil {
+		log.Printf("Ошибка загрузки частиц: %v", err)
+	}
+	
+	// Загружаем игровые числа; при ошибке играем со значениями по умолчанию
+	tuning, err := loadTuning(assetManager)
+	if err != nil {
+		log.Printf("Ошибка загрузки настройки игры: %v", err)
+		tuning = game.DefaultTuning()
+	}
 	
 	// Создаем новую игру
 	game := &Game{
 		stateMachine: states.NewStateMachine(),
 		player:       gamePlayer,
 		debugSystem:  debugSystem,
+		settings:     settings,
+		sound:        soundManager,
+		assets:       assetManager,
+		console:      console.New(),
 	}
 
 	// Создаем и добавляем состояние меню
-	menuState := states.NewMenuState(game.stateMachine)
-	game.stateMachine.Add("menu", menuState)
+	menuState := states.NewMenuState(game.stateMachine, soundManager)
+	game.stateMachine.Add(states.StateMenu, menuState)
 
 	// Создаем и добавляем игровое состояние
-	playState := states.NewPlayState(game.stateMachine, game.player) // Передаем игрока в игровое состояние
-	game.stateMachine.Add("playing", playState)
+	playState := states.NewPlayState(game.stateMachine, game.player, gameProfile, soundManager, particlePresets, camera.NewCamera(settings), ui.NewHUD(&settings.HUD), assetManager, tuning) // Передаем игрока и профиль в игровое состояние
+	game.stateMachine.Add(states.StatePlaying, playState)
+	game.playState = playState
+
+	// Создаем и добавляем паузу, которая показывается поверх игры
+	pauseState := states.NewPauseState(game.stateMachine, playState)
+	game.stateMachine.Add(states.StatePaused, pauseState)
+
+	// Создаем и добавляем выбор улучшений при повышении уровня
+	levelUpState := states.NewLevelUpState(game.stateMachine, playState)
+	game.stateMachine.Add(states.StateLevelUp, levelUpState)
+
+	// Создаем и добавляем экран настроек
+	settingsState := states.NewSettingsState(game.stateMachine, settings)
+	game.stateMachine.Add(states.StateSettings, settingsState)
+
+	// Создаем и добавляем таблицу рекордов
+	leaderboardState := states.NewLeaderboardState(game.stateMachine, gameProfile)
+	game.stateMachine.Add(states.StateLeaderboard, leaderboardState)
+
+	// Создаем и добавляем экран статистики
+	statsState := states.NewStatsState(game.stateMachine, gameProfile)
+	game.stateMachine.Add(states.StateStats, statsState)
 
 	// Создаем и добавляем состояние смерти
-	deathState := states.NewDeathState(game.stateMachine)
-	game.stateMachine.Add("death", deathState)
+	deathState := states.NewDeathState(game.stateMachine, game.player, gameProfile, soundManager)
+	game.stateMachine.Add(states.StateDeath, deathState)
 
 	// Устанавливаем начальное состояние (меню)
-	game.stateMachine.ChangeState("menu")
+	if err := game.stateMachine.ChangeState(states.StateMenu, nil); err != nil {
+		log.Fatal(err)
+	}
 
 	return game
 }
 
+// loadFont загружает шрифт; при ошибке менеджер подставляет встроенный шрифт по умолчанию
+func loadFont(assetManager *assets.Manager, name string) *text.Font {
+	source, err := assetManager.Font(name)
+	if err != nil {
+		log.Printf("Ошибка загрузки шрифта: %v", err)
+	}
+	if source == nil {
+		return nil
+	}
+	return text.NewFont(source)
+}
+
+// loadParticlePresets загружает пресеты частиц из assets/particles.json
+func loadParticlePresets(assetManager *assets.Manager) (map[string]*particles.Preset, error) {
+	data, err := assetManager.ReadFile("particles.json")
+	if err != nil {
+		return nil, err
+	}
+	return particles.ParsePresets(data)
+}
+
+// loadTuning загружает игровые числа из assets/tuning.json
+func loadTuning(assetManager *assets.Manager) (*game.Tuning, error) {
+	data, err := assetManager.ReadFile("tuning.json")
+	if err != nil {
+		return nil, err
+	}
+	return game.ParseTuning(data)
+}
+
+// loadLocale загружает таблицу строк языка из assets/locales/<code>.json
+func loadLocale(assetManager *assets.Manager, code string) (*i18n.Locale, error) {
+	data, err := assetManager.ReadFile("locales/" + code + ".json")
+	if err != nil {
+		return nil, err
+	}
+	return i18n.ParseLocale(code, data)
+}
+
+// loadLocales загружает таблицы строк всех языков, доступных в настройках
+func loadLocales(assetManager *assets.Manager) *i18n.Catalog {
+	catalog := i18n.NewCatalog()
+	for _, code := range config.Languages {
+		locale, err := loadLocale(assetManager, code)
+		if err != nil {
+			log.Printf("Ошибка загрузки языка %s: %v", code, err)
+			continue
+		}
+		catalog.Add(locale)
+	}
+	return catalog
+}
+
+// reload перечитывает изменённый файл ресурсов, не прерывая забег,
+// и показывает ошибку разбора поверх игры до следующей удачной правки
+func (g *Game) reload(name string) {
+	var err error
+	switch name {
+	case "tuning.json":
+		var tuning *game.Tuning
+		if tuning, err = loadTuning(g.assets); err == nil {
+			g.playState.ApplyTuning(tuning)
+		}
+	case "particles.json":
+		var presets map[string]*particles.Preset
+		if presets, err = loadParticlePresets(g.assets); err == nil {
+			g.playState.SetParticlePresets(presets)
+		}
+	default:
+		// Таблица строк заменяется целиком; новые строки видны со следующего кадра
+		if file, ok := strings.CutPrefix(name, "locales/"); ok {
+			var locale *i18n.Locale
+			if locale, err = loadLocale(g.assets, strings.TrimSuffix(file, ".json")); err == nil {
+				i18n.Default().Add(locale)
+				for _, missing := range i18n.Default().Missing() {
+					log.Printf("Нет перевода: %s", missing)
+				}
+			}
+			break
+		}
+		var reloaded bool
+		reloaded, err = g.assets.Reload(name)
+		if !reloaded {
+			// Файл ещё не загружался - игра прочитает новую версию сама
+			return
+		}
+	}
+	
+	g.debugSystem.SetError(name, err)
+	if err != nil {
+		log.Printf("Ошибка перезагрузки %s: %v", name, err)
+		return
+	}
+	log.Printf("Перезагружен %s", name)
+}
+
 // Update обновляет игровую логику (реализация интерфейса ebiten.Game)
 func (g *Game) Update() error {
+	// При закрытии окна сохраняем незавершённый забег
+	if ebiten.IsWindowBeingClosed() {
+		if g.playState.InProgress() {
+			if err := g.playState.SaveRun(); err != nil {
+				log.Printf("Не удалось сохранить забег: %v", err)
+			}
+		}
+		if err := g.settings.Save(); err != nil {
+			log.Printf("Не удалось сохранить настройки: %v", err)
+		}
+		return ebiten.Termination
+	}
+
+	// Начинаем новый кадр профилировщика
+	profiler.FrameStart()
+
+	// Перечитываем изменённые ресурсы
+	if g.watcher != nil {
+		g.watcher.Update()
+	}
+	
+	// Обновляем громкость и переходы музыки
+	g.sound.Update()
+	
+	// Пока консоль открыта, ввод принадлежит ей, а игра стоит
+	input := profiler.Begin("input")
+	if g.console.Update() {
+		input.End()
+		return nil
+	}
+	
 	// Переключение режима отладки по F1
 	if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
 		g.debugSystem.Toggle()
@@ -67,25 +282,57 @@ func (g *Game) Update() error {
 		g.debugSystem.ShowHitboxes = !g.debugSystem.ShowHitboxes
 	}
 	
+	// Переключение просмотра атласа по F4
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) && g.debugSystem.IsEnabled() {
+		g.debugSystem.ShowAtlas = !g.debugSystem.ShowAtlas
+	}
+	
+	// Переключение профилировщика по F5
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) && g.debugSystem.IsEnabled() {
+		profiler.SetEnabled(!profiler.Enabled())
+	}
+	
+	// Переключение инспектора объектов по F6
+	if inpututil.IsKeyJustPressed(ebiten.KeyF6) && g.debugSystem.IsEnabled() {
+		g.debugSystem.ShowInspector = !g.debugSystem.ShowInspector
+	}
+	input.End()
+	
 	// Делегируем обновление логики текущему состоянию
+	update := profiler.Begin("update")
+	defer update.End()
 	return g.stateMachine.Update()
 }
 
 // Draw отрисовывает игру (реализация интерфейса ebiten.Game)
 func (g *Game) Draw(screen *ebiten.Image) {
 	// Делегируем отрисовку текущему состоянию
+	draw := profiler.Begin("draw")
 	g.stateMachine.Draw(screen)
+	draw.End()
 
 	// Если включен режим отладки, выполняем отладочные действия
 	if g.debugSystem.IsEnabled() {
 		// Отображаем отладочную информацию
         g.debugSystem.DrawDebugInfo(screen, ebiten.ActualTPS())
         
+        // Страницы атласа поверх игры
+        g.debugSystem.DrawAtlas(screen, g.assets.Atlas)
+        
         // Добавляем информацию о текущем состоянии
 		currentState := g.stateMachine.GetCurrentStateName()
-		g.debugSystem.AddMessage("Текущее состояние: " + currentState)
+		g.debugSystem.AddMessage(i18n.T("debug.state", currentState))
 	}
 
+	// Ошибки перезагрузки ресурсов видны всегда
+	g.debugSystem.DrawErrors(screen)
+	
+	// Панель профилировщика, пока он включён
+	profiler.Draw(screen)
+	
+	// Консоль поверх всего
+	g.console.Draw(screen)
+
 	// Отрисовка игрока - это должно происходить в соответствующем состоянии,
 	// но для простоты можно оставить здесь
 	// g.player.Draw(screen)
@@ -93,17 +340,68 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 // Layout определяет логический размер игры (реализация интерфейса ebiten.Game)
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	// Возвращаем фиксированный размер игрового мира
-	return 1280, 960
+	// Логический размер зависит от разрешения в настройках и, в режиме расширения, от пропорций окна
+	return viewport.Current().Layout(outsideWidth, outsideHeight)
 }
 
 func main() {
+	// Загружаем настройки до открытия окна; при ошибке используются значения по умолчанию
+	settings, err := config.Load()
+	if err != nil {
+		log.Printf("Ошибка загрузки настроек: %v", err)
+	}
+
+	// Ресурсы встроены в исполняемый файл; -assets подменяет их файлами из каталога
+	assetDir := flag.String("assets", "", "каталог с ресурсами, заменяющими встроенные")
+	hotReload := flag.Bool("hotreload", false, "перечитывать изменённые ресурсы без перезапуска (только в сборке с -tags dev)")
+	checkLocales := flag.Bool("check-locales", false, "вывести строки, которых нет в каком-либо языке, и выйти")
+	flag.Parse()
+	
+	// Горячая перезагрузка читает файлы с диска, поэтому по умолчанию следит за каталогом assets
+	if *hotReload && !hotreload.Enabled {
+		log.Printf("Горячая перезагрузка недоступна: игра собрана без -tags dev")
+		*hotReload = false
+	}
+	if *hotReload && *assetDir == "" {
+		*assetDir = "assets"
+	}
+	
+	assetManager := assets.NewManager(*assetDir)
+	
+	// Загружаем строки интерфейса; недостающие строки берутся из английского
+	catalog := loadLocales(assetManager)
+	missing := catalog.Missing()
+	if *checkLocales {
+		for _, key := range missing {
+			fmt.Println(key)
+		}
+		if len(missing) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	for _, key := range missing {
+		log.Printf("Нет перевода: %s", key)
+	}
+	i18n.SetCatalog(catalog)
+	if err := catalog.SetLocale(settings.Language); err != nil {
+		log.Printf("Ошибка выбора языка: %v", err)
+	}
+	
 	// Создаем новую игру
-	game := NewGame()
+	game := NewGame(settings, assetManager)
+	if *hotReload {
+		game.watcher = hotreload.NewWatcher(*assetDir, game.reload)
+	}
 	
 	// Настраиваем окно игры
-	ebiten.SetWindowSize(1280, 960)
+	settings.ApplyWindow()
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetWindowTitle("SuperPuperGame")
+	ebiten.SetWindowClosingHandled(true)
+	
+	// Игра продолжает обновляться без фокуса, чтобы поставить себя на паузу
+	ebiten.SetRunnableOnUnfocused(true)
 	
 	// Запускаем игровой цикл
 	if err := ebiten.RunGame(game); err != nil {
@@ -0,0 +1,74 @@
+// Пакет particles реализует систему частиц для визуальных эффектов
+package particles
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// maxDrag - сопротивление, при котором частица теряет всю скорость за один кадр
+const maxDrag = 60
+
+// Range - диапазон, из которого случайно выбирается значение
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Color - цвет частицы, компоненты от 0 до 1
+type Color struct {
+	R float32 `json:"r"`
+	G float32 `json:"g"`
+	B float32 `json:"b"`
+	A float32 `json:"a"`
+}
+
+// lerp возвращает цвет между c и to в доле t
+func (c Color) lerp(to Color, t float32) Color {
+	return Color{
+		R: c.R + (to.R-c.R)*t,
+		G: c.G + (to.G-c.G)*t,
+		B: c.B + (to.B-c.B)*t,
+		A: c.A + (to.A-c.A)*t,
+	}
+}
+
+// Preset описывает, какие частицы испускает эмиттер
+type Preset struct {
+	Count    int     `json:"count"`    // Количество частиц во вспышке
+	Rate     float64 `json:"rate"`     // Частиц в секунду у непрерывного эмиттера
+	Lifetime Range   `json:"lifetime"` // Время жизни частицы (в секундах)
+	Speed    Range   `json:"speed"`    // Начальная скорость (пикселей в секунду)
+	Angle    float64 `json:"angle"`    // Направление вылета (в градусах, 0 - вправо, 90 - вниз)
+	Spread   float64 `json:"spread"`   // Разброс направления (в градусах, 360 - во все стороны)
+	Gravity  float64 `json:"gravity"`  // Ускорение вниз (пикселей в секунду за секунду)
+	Drag     float64 `json:"drag"`     // Доля скорости, теряемая за секунду (от 0 до maxDrag)
+	Radius   float64 `json:"radius"`   // Радиус области появления частиц
+
+	StartColor Color   `json:"start_color"` // Цвет в начале жизни
+	EndColor   Color   `json:"end_color"`   // Цвет в конце жизни
+	StartSize  float64 `json:"start_size"`  // Размер в начале жизни (в пикселях)
+	EndSize    float64 `json:"end_size"`    // Размер в конце жизни (в пикселях)
+	Additive   bool    `json:"additive"`    // Складывать цвет с фоном (свечение)
+}
+
+// ParsePresets разбирает набор пресетов из JSON
+func ParsePresets(data []byte) (map[string]*Preset, error) {
+	presets := make(map[string]*Preset)
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("повреждённые пресеты частиц: %w", err)
+	}
+	for name, preset := range presets {
+		switch {
+		case preset == nil:
+			return nil, fmt.Errorf("пресет %q: пустой пресет", name)
+		case preset.Lifetime.Max <= 0:
+			return nil, fmt.Errorf("пресет %q: время жизни должно быть больше нуля", name)
+		case preset.Count < 0 || preset.Rate < 0:
+			return nil, fmt.Errorf("пресет %q: количество частиц не может быть отрицательным", name)
+		case preset.Drag < 0 || preset.Drag > maxDrag:
+			return nil, fmt.Errorf("пресет %q: сопротивление должно быть от 0 до %d", name, maxDrag)
+		}
+	}
+	return presets, nil
+}
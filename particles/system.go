@@ -0,0 +1,253 @@
+// Пакет particles реализует систему частиц для визуальных эффектов
+package particles
+
+import (
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"math/rand/v2"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultCapacity - размер пула частиц по умолчанию
+const DefaultCapacity = 8192
+
+// maxCapacity - наибольший размер пула, при котором индексы вершин помещаются в uint16
+const maxCapacity = 65536 / 4
+
+// whiteImage - текстура для частиц; берётся середина белого изображения 3x3,
+// чтобы края соседних пикселей не попадали в выборку
+var whiteImage = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3)
+	img.Fill(color.White)
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}()
+
+// particle - одна частица в пуле
+type particle struct {
+	x, y     float64
+	vx, vy   float64
+	age      float64
+	lifetime float64
+	preset   *Preset
+}
+
+// Emitter - непрерывный источник частиц, например след за рывком
+type Emitter struct {
+	// X, Y - точка появления частиц
+	X, Y float64
+
+	// Active - испускает ли эмиттер частицы
+	Active bool
+
+	// name - имя пресета, чтобы найти его заново после замены пресетов
+	name string
+
+	// preset - описание частиц; nil, если пресет не найден
+	preset *Preset
+
+	// accumulator - накопленная дробная часть частиц между кадрами
+	accumulator float64
+}
+
+// System хранит пул частиц и эмиттеры и отрисовывает все частицы
+// двумя вызовами DrawTriangles: обычные и с аддитивным смешиванием
+type System struct {
+	// presets - доступные пресеты по имени
+	presets map[string]*Preset
+
+	// particles - пул частиц; живые частицы занимают первые alive элементов
+	particles []particle
+	alive     int
+
+	// emitters - непрерывные эмиттеры
+	emitters []*Emitter
+
+	// vertices, indices - переиспользуемые буферы вершин
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+// NewSystem создаёт систему частиц с пулом указанного размера
+func NewSystem(presets map[string]*Preset, capacity int) *System {
+	capacity = min(capacity, maxCapacity)
+	return &System{
+		presets:   presets,
+		particles: make([]particle, capacity),
+	}
+}
+
+// preset находит пресет по имени; отсутствие пресета записывается в журнал один раз
+func (s *System) preset(name string) *Preset {
+	preset, ok := s.presets[name]
+	if !ok {
+		log.Printf("Пресет частиц %q не найден", name)
+		if s.presets == nil {
+			s.presets = make(map[string]*Preset)
+		}
+		s.presets[name] = nil
+	}
+	return preset
+}
+
+// Burst выпускает разом Count частиц пресета в точке (x, y)
+func (s *System) Burst(name string, x, y float64) {
+	preset := s.preset(name)
+	if preset == nil {
+		return
+	}
+	for i := 0; i < preset.Count; i++ {
+		s.spawn(preset, x, y)
+	}
+}
+
+// NewEmitter создаёт непрерывный эмиттер пресета; изначально он выключен
+func (s *System) NewEmitter(name string) *Emitter {
+	emitter := &Emitter{name: name, preset: s.preset(name)}
+	s.emitters = append(s.emitters, emitter)
+	return emitter
+}
+
+// SetPresets заменяет пресеты, например после правки файла во время игры.
+// Эмиттеры переключаются на новые пресеты, а живые частицы доживают со старыми параметрами.
+func (s *System) SetPresets(presets map[string]*Preset) {
+	s.presets = presets
+	for _, emitter := range s.emitters {
+		emitter.preset = s.preset(emitter.name)
+	}
+}
+
+// Clear удаляет все частицы; эмиттеры выключаются
+func (s *System) Clear() {
+	s.alive = 0
+	for _, emitter := range s.emitters {
+		emitter.Active = false
+		emitter.accumulator = 0
+	}
+}
+
+// Count возвращает количество живых частиц
+func (s *System) Count() int {
+	return s.alive
+}
+
+// spawn добавляет частицу в пул; при переполнении новая частица не появляется
+func (s *System) spawn(preset *Preset, x, y float64) {
+	if s.alive >= len(s.particles) {
+		return
+	}
+
+	// Точка появления - случайная точка в круге радиуса Radius
+	if preset.Radius > 0 {
+		angle := rand.Float64() * 2 * math.Pi
+		r := preset.Radius * math.Sqrt(rand.Float64())
+		x += math.Cos(angle) * r
+		y += math.Sin(angle) * r
+	}
+
+	angle := (preset.Angle + (rand.Float64()-0.5)*preset.Spread) * math.Pi / 180
+	speed := randomIn(preset.Speed)
+	s.particles[s.alive] = particle{
+		x:        x,
+		y:        y,
+		vx:       math.Cos(angle) * speed,
+		vy:       math.Sin(angle) * speed,
+		lifetime: math.Max(randomIn(preset.Lifetime), 1.0/60.0),
+		preset:   preset,
+	}
+	s.alive++
+}
+
+// Update продвигает частицы и эмиттеры на один кадр
+func (s *System) Update() {
+	const dt = 1.0 / 60.0
+
+	// Эмиттеры испускают Rate частиц в секунду
+	for _, emitter := range s.emitters {
+		if !emitter.Active || emitter.preset == nil {
+			continue
+		}
+		emitter.accumulator += emitter.preset.Rate * dt
+		for emitter.accumulator >= 1 {
+			s.spawn(emitter.preset, emitter.X, emitter.Y)
+			emitter.accumulator--
+		}
+	}
+
+	// Умершие частицы заменяются последней живой, поэтому пул не фрагментируется
+	for i := 0; i < s.alive; {
+		p := &s.particles[i]
+		p.age += dt
+		if p.age >= p.lifetime {
+			s.alive--
+			s.particles[i] = s.particles[s.alive]
+			continue
+		}
+
+		p.vy += p.preset.Gravity * dt
+		damping := math.Max(0, 1-p.preset.Drag*dt)
+		p.vx *= damping
+		p.vy *= damping
+		p.x += p.vx * dt
+		p.y += p.vy * dt
+		i++
+	}
+}
+
+// Draw отрисовывает все частицы
+func (s *System) Draw(screen *ebiten.Image) {
+	s.drawBatch(screen, false)
+	s.drawBatch(screen, true)
+}
+
+// drawBatch отрисовывает частицы с указанным режимом смешивания одним вызовом DrawTriangles
+func (s *System) drawBatch(screen *ebiten.Image, additive bool) {
+	s.vertices = s.vertices[:0]
+	s.indices = s.indices[:0]
+
+	for i := 0; i < s.alive; i++ {
+		p := &s.particles[i]
+		if p.preset.Additive != additive {
+			continue
+		}
+
+		t := p.age / p.lifetime
+		c := p.preset.StartColor.lerp(p.preset.EndColor, float32(t))
+		half := float32((p.preset.StartSize + (p.preset.EndSize-p.preset.StartSize)*t) / 2)
+		x, y := float32(p.x), float32(p.y)
+
+		base := uint16(len(s.vertices))
+		s.vertices = append(s.vertices,
+			vertex(x-half, y-half, c),
+			vertex(x+half, y-half, c),
+			vertex(x-half, y+half, c),
+			vertex(x+half, y+half, c),
+		)
+		s.indices = append(s.indices, base, base+1, base+2, base+1, base+3, base+2)
+	}
+
+	if len(s.indices) == 0 {
+		return
+	}
+	op := &ebiten.DrawTrianglesOptions{}
+	if additive {
+		op.Blend = ebiten.BlendLighter
+	}
+	screen.DrawTriangles(s.vertices, s.indices, whiteImage, op)
+}
+
+// vertex создаёт вершину частицы указанного цвета
+func vertex(x, y float32, c Color) ebiten.Vertex {
+	return ebiten.Vertex{
+		DstX: x, DstY: y,
+		SrcX: 1.5, SrcY: 1.5,
+		ColorR: c.R, ColorG: c.G, ColorB: c.B, ColorA: c.A,
+	}
+}
+
+// randomIn возвращает случайное значение из диапазона
+func randomIn(r Range) float64 {
+	return r.Min + rand.Float64()*(r.Max-r.Min)
+}
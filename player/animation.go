@@ -1,27 +1,37 @@
 package player
 
 import (
-	"image"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-// UpdateAnimation обновляет анимацию игрока
+// facingName возвращает суффикс клипа для направления взгляда:
+// влево и вправо используют один клип, отражённый при отрисовке
+func (p *Player) facingName() string {
+	switch p.Facing {
+	case DirUp:
+		return "up"
+	case DirLeft, DirRight:
+		return "side"
+	default:
+		return "down"
+	}
+}
+
+// UpdateAnimation выбирает клип по состоянию игрока и продвигает анимацию
 func (p *Player) UpdateAnimation() {
-	// Обновляем анимацию движения
-	if p.DirX != 0 || p.DirY != 0 {
-		// Увеличиваем счетчик кадров
-		p.FrameCount++
-		
-		// Меняем кадр анимации каждые 10 обновлений
-		if p.FrameCount % 10 == 0 {
-			p.FrameX = (p.FrameX + 1) % FramesPerState
-		}
-	} else {
-		// Если игрок стоит на месте, используем первый кадр
-		p.FrameX = 0
+	// Выбираем клип: атака важнее движения, движение важнее покоя
+	switch {
+	case p.Attacking:
+		p.Animator.Play("attack_" + p.facingName())
+	case p.DirX != 0 || p.DirY != 0:
+		p.Animator.Play("walk_" + p.facingName())
+	default:
+		p.Animator.Play("idle_" + p.facingName())
 	}
+	
+	p.Animator.Update()
 }
 
 // UpdateDeathAnimation обновляет анимацию смерти
@@ -34,31 +44,21 @@ func (p *Player) UpdateDeathAnimation() {
 	// Увеличиваем таймер смерти
 	p.DeathTimer += 1.0 / 60.0
 	
-	// Замедленная анимация падения
-	if p.DeathTimer > 0.5 && p.FrameCount%20 == 0 {
-		p.FrameX = (p.FrameX + 1) % FramesPerState
-	}
-	
-	// Обновляем счетчик кадров
-	p.FrameCount++
+	// Клип смерти однократный и останавливается на последнем кадре
+	p.Animator.Update()
 }
 
 // StartDeathAnimation запускает анимацию смерти
 func (p *Player) StartDeathAnimation() {
 	p.Dying = true      // Устанавливаем флаг смерти
 	p.DeathTimer = 0    // Сбрасываем таймер
-	p.FrameX = 0        // Начинаем с первого кадра
-	p.FrameY = 0        // Используем кадры для основного состояния
+	p.Animator.Restart("death_" + p.facingName())
 }
 
 // DrawSprite отрисовывает спрайт игрока с учетом текущего состояния
 func (p *Player) DrawSprite(screen *ebiten.Image) {
-	// Вырезаем текущий кадр из спрайт-листа
-	r := image.Rect(
-		p.FrameX*FrameWidth, p.FrameY*FrameHeight, 
-		(p.FrameX+1)*FrameWidth, (p.FrameY+1)*FrameHeight,
-	)
-	subImage := p.SpriteSheet.SubImage(r).(*ebiten.Image)
+	// Текущий кадр клипа
+	subImage := p.Animator.Image()
 	
 	// Рассчитываем масштабированные размеры
 	scaledWidth := float64(FrameWidth) * ScaleFactor
@@ -78,19 +78,12 @@ func (p *Player) DrawSprite(screen *ebiten.Image) {
 	op.GeoM.Scale(ScaleFactor, ScaleFactor)
 	
 	// Отражаем спрайт для направления влево
-	if p.DirX < 0 && !p.Dying {
+	if p.Facing == DirLeft {
 		op.GeoM.Scale(-1, 1) // Отражение по горизонтали
 	}
 	
-	// Добавляем эффекты для анимации смерти
+	// Падение показывает клип смерти, поверх него игрок постепенно тускнеет
 	if p.Dying {
-		// Эффект падения (поворот)
-		rotationAngle := p.DeathTimer * 0.3 // Медленное падение
-		if rotationAngle > math.Pi/2 {
-			rotationAngle = math.Pi/2 // Ограничиваем поворот до 90 градусов
-		}
-		op.GeoM.Rotate(rotationAngle)
-		
 		// Эффект затухания (прозрачность)
 		opacity := 1.0 - math.Min(p.DeathTimer/3.0, 0.5)
 		op.ColorM.Scale(1, 1, 1, opacity)
@@ -2,28 +2,23 @@ package player
 
 import (
 	"math"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/viewport"
 )
 
-// UpdateCombat обрабатывает атаку игрока
-func (p *Player) UpdateCombat() {
-	// Определяем направление атаки по позиции курсора
-	cursorX, cursorY := ebiten.CursorPosition()
-	
-	// Преобразование координат курсора из оконных в игровые
-	gameWidth, gameHeight := 1280.0, 960.0
-	w, h := ebiten.WindowSize()
-	windowWidth, windowHeight := float64(w), float64(h)
-	
-	// Корректируем координаты курсора в соответствии с масштабом окна
-	cursorX = int(float64(cursorX) * gameWidth / windowWidth)
-	cursorY = int(float64(cursorY) * gameHeight / windowHeight)
+// AttackDuration - длительность одного взмаха меча (в секундах)
+const AttackDuration = 0.3
+
+// UpdateCombat обрабатывает атаку игрока; input - нажатия, накопленные к тику
+func (p *Player) UpdateCombat(input Input) {
+	// Определяем направление атаки по позиции курсора; курсор уже в логических координатах экрана,
+	// остаётся перевести его в координаты арены
+	cursorX, cursorY := viewport.CursorArena()
 	
 	// Вычисляем вектор от игрока до курсора
-	dx := float64(cursorX) - (p.X + 10)
-	dy := float64(cursorY) - (p.Y + 10)
+	dx := cursorX - (p.X + 10)
+	dy := cursorY - (p.Y + 10)
 	
 	// Нормализуем вектор направления
 	length := math.Sqrt(dx*dx + dy*dy)
@@ -35,22 +30,17 @@ func (p *Player) UpdateCombat() {
 	// Запоминаем угол атаки
 	p.AttackAngle = math.Atan2(dy, dx)
 	
+	// Отсчитываем кулдаун атаки тиками, чтобы он следовал скорости симуляции
+	p.AttackRecharge = math.Max(0, p.AttackRecharge-1.0/60.0)
+	
 	// Обработка нажатия левой кнопки мыши для атаки
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !p.Attacking {
-		// Проверяем время с последней атаки (кулдаун)
-		if time.Since(p.LastAttackTime) >= 500*time.Millisecond {
+	if input.Attack && !p.Attacking {
+		// Проверяем, прошёл ли кулдаун
+		if p.AttackRecharge <= 0 {
 			// Активируем атаку
 			p.Attacking = true
 			p.AttackTimer = 0
-			p.LastAttackTime = time.Now()
-			p.FrameX = 0  // Сбрасываем кадр анимации
-			
-			// Устанавливаем длительность атаки
-			go func() {
-				time.Sleep(300 * time.Millisecond)
-				p.Attacking = false
-				p.FrameX = 0
-			}()
+			p.AttackRecharge = p.AttackCooldown
 		}
 	}
 	
@@ -58,9 +48,9 @@ func (p *Player) UpdateCombat() {
 	if p.Attacking {
 		p.AttackTimer += 1.0 / 60.0  // Увеличиваем таймер (60 FPS)
 		
-		// Обновляем анимацию атаки
-		if p.FrameCount%10 == 0 {
-			p.FrameX = (p.FrameX + 1) % FramesPerState
+		// Завершаем атаку по истечении её длительности
+		if p.AttackTimer >= AttackDuration {
+			p.Attacking = false
 		}
 	}
 }
@@ -75,7 +65,7 @@ func (p *Player) DrawSword(screen *ebiten.Image) {
 	opSword := &ebiten.DrawImageOptions{}
 	
 	// Центрируем меч относительно его середины
-	swordWidth := float64(p.SwordImage.Bounds().Dx())
+	swordWidth := float64(p.Sword.Rect.Dx())
 	opSword.GeoM.Translate(-swordWidth/2, 0)
 	
 	// Поворот меча на нужный угол (+90 градусов для правильной ориентации)
@@ -93,7 +83,7 @@ func (p *Player) DrawSword(screen *ebiten.Image) {
 	
 	// Применяем смещение и отрисовываем меч
 	opSword.GeoM.Translate(swordX, swordY)
-	screen.DrawImage(p.SwordImage, opSword)
+	screen.DrawImage(p.Sword.Image(), opSword)
 }
 
 // AttackArea возвращает область атаки меча как прямоугольник
@@ -109,8 +99,8 @@ func (p *Player) AttackArea() (x, y, width, height float64) {
 		offsetY := math.Sin(p.AttackAngle) * offsetDistance
 		
 		// Получаем размеры меча
-		swordLength := float64(p.SwordImage.Bounds().Dx())
-		swordWidth := float64(p.SwordImage.Bounds().Dy())
+		swordLength := float64(p.Sword.Rect.Dx())
+		swordWidth := float64(p.Sword.Rect.Dy())
 		
 		// Начальная позиция меча (рукоять)
 		swordX := p.X + 10 + offsetX
@@ -135,4 +125,12 @@ func (p *Player) AttackArea() (x, y, width, height float64) {
 	
 	// Если игрок не атакует или умирает, возвращаем нулевую область
 	return 0, 0, 0, 0
-}
\ No newline at end of file
+}
+
+// AttackReady возвращает готовность атаки после перезарядки (0..1)
+func (p *Player) AttackReady() float64 {
+	if p.AttackCooldown <= 0 {
+		return 1
+	}
+	return math.Max(0, 1-p.AttackRecharge/p.AttackCooldown)
+}
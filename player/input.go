@@ -0,0 +1,40 @@
+package player
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Input - нажатия атаки и рывка, накопленные между тиками.
+// Кадр и тик совпадают только при обычной скорости симуляции: при замедлении или пошаговом
+// режиме в кадре может не быть тика, поэтому нажатия копятся до ближайшего тика.
+type Input struct {
+	Attack bool // Кнопка атаки была нажата хотя бы в одном кадре с прошлого тика
+	Dash   bool // Клавиша рывка была нажата хотя бы в одном кадре с прошлого тика
+}
+
+// SampleInput запоминает нажатия текущего кадра; вызывается каждый кадр, даже если тиков в нём нет
+func (p *Player) SampleInput() {
+	attack, dash := p.heldInput()
+	p.input.Attack = p.input.Attack || attack
+	p.input.Dash = p.input.Dash || dash
+}
+
+// takeInput возвращает нажатия, накопленные к этому тику, и оставляет в буфере только то,
+// что удерживается сейчас: следующий тик того же кадра видит удержание, но не отпущенную кнопку
+func (p *Player) takeInput() Input {
+	input := p.input
+	p.input.Attack, p.input.Dash = p.heldInput()
+	return input
+}
+
+// ResetInput забывает накопленные нажатия
+func (p *Player) ResetInput() {
+	p.input = Input{}
+}
+
+// heldInput сообщает, удерживаются ли сейчас кнопка атаки и клавиша рывка
+func (p *Player) heldInput() (attack, dash bool) {
+	attack = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !p.MouseCaptured
+	dash = ebiten.IsKeyPressed(p.Keys.Dash)
+	return attack, dash
+}
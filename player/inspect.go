@@ -0,0 +1,59 @@
+package player
+
+import (
+	"fmt"
+
+	"superpupergame/debug"
+)
+
+// InspectName возвращает вид объекта для отладочного инспектора
+func (p *Player) InspectName() string {
+	return "player"
+}
+
+// Inspect возвращает свойства игрока для отладочного инспектора
+func (p *Player) Inspect() []debug.Property {
+	return []debug.Property{
+		debug.ReadOnly("state", p.state),
+		debug.ReadOnly("animation", p.Animator.ClipName),
+		debug.Float("x", &p.X),
+		debug.Float("y", &p.Y),
+		debug.ReadOnly("velocity", func() string {
+			speed := p.Speed
+			if p.Dashing {
+				speed = p.DashSpeed
+			}
+			return fmt.Sprintf("%.2f, %.2f", p.DirX*speed, p.DirY*speed)
+		}),
+		debug.Float("speed", &p.Speed),
+		debug.Float("dash_speed", &p.DashSpeed),
+		debug.Float("health", &p.Health),
+		debug.Float("max_health", &p.MaxHealth),
+		debug.Bool("attacking", &p.Attacking),
+		debug.Float("attack_timer", &p.AttackTimer),
+		debug.Float("attack_cooldown", &p.AttackCooldown),
+		debug.Float("attack_recharge", &p.AttackRecharge),
+		debug.Bool("dashing", &p.Dashing),
+		debug.Float("dash_timer", &p.DashTimer),
+		debug.Int("dash_charges", &p.DashCharges),
+		debug.Int("max_dashes", &p.MaxDashes),
+		debug.ReadOnly("dash_recharge", func() string { return fmt.Sprintf("%.2f", p.DashRecharge) }),
+		debug.Float("death_timer", &p.DeathTimer),
+	}
+}
+
+// state возвращает текущее состояние игрока словом
+func (p *Player) state() string {
+	switch {
+	case p.Dying:
+		return "dying"
+	case p.Dashing:
+		return "dash"
+	case p.Attacking:
+		return "attack"
+	case p.DirX != 0 || p.DirY != 0:
+		return "move"
+	default:
+		return "idle"
+	}
+}
@@ -1,33 +1,42 @@
 package player
 
 import (
+	"cmp"
 	"math"
-	"time"
+	"slices"
 	
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-// UpdateMovement обрабатывает движение игрока и рывки
-func (p *Player) UpdateMovement() {
+// Длительности рывка (в секундах)
+const (
+	DashDuration     = 0.2 // Длительность одного рывка
+	DashRechargeTime = 5.0 // Время восстановления одного заряда
+)
+
+// UpdateMovement обрабатывает движение игрока и рывки; input - нажатия, накопленные к тику
+func (p *Player) UpdateMovement(input Input) {
 	// Сбрасываем направление движения
 	p.DirX, p.DirY = 0, 0
 	
 	// Обработка клавиш направления
-	if ebiten.IsKeyPressed(ebiten.KeyW) {
+	// При диагональном движении горизонтальное направление важнее для анимации,
+	// поэтому оно проверяется последним
+	if ebiten.IsKeyPressed(p.Keys.Up) {
 		p.DirY = -1         // Движение вверх
-		p.FrameY = 2        // Устанавливаем кадр анимации для направления вверх
+		p.Facing = DirUp
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyS) {
+	if ebiten.IsKeyPressed(p.Keys.Down) {
 		p.DirY = 1          // Движение вниз
-		p.FrameY = 3        // Устанавливаем кадр анимации для направления вниз
+		p.Facing = DirDown
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyA) {
+	if ebiten.IsKeyPressed(p.Keys.Left) {
 		p.DirX = -1         // Движение влево
-		p.FrameY = 4        // Устанавливаем кадр анимации для направления влево
+		p.Facing = DirLeft
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyD) {
+	if ebiten.IsKeyPressed(p.Keys.Right) {
 		p.DirX = 1          // Движение вправо
-		p.FrameY = 4        // Устанавливаем кадр анимации для направления вправо
+		p.Facing = DirRight
 	}
 	
 	// Нормализация вектора движения при диагональном движении
@@ -37,23 +46,6 @@ func (p *Player) UpdateMovement() {
 		// Нормализуем вектор для равномерной скорости по диагонали
 		p.DirX = p.DirX / magnitude
 		p.DirY = p.DirY / magnitude
-		
-		// Выбираем приоритетное направление для анимации
-		if math.Abs(p.DirX) > math.Abs(p.DirY) {
-			// Движение больше по горизонтали
-			if p.DirX < 0 {
-				p.FrameY = 3  // Влево
-			} else {
-				p.FrameY = 0  // Вправо
-			}
-		} else {
-			// Движение больше по вертикали
-			if p.DirY < 0 {
-				p.FrameY = 2  // Вверх
-			} else {
-				p.FrameY = 0  // Вниз
-			}
-		}
 	}
 	
 	// Применяем текущую скорость движения
@@ -67,17 +59,20 @@ func (p *Player) UpdateMovement() {
 	p.Y += p.DirY * currentSpeed
 	
 	// Обработка рывка (dash)
-	p.handleDash()
+	p.handleDash(input)
+	
+	// Обновляем таймеры рывка и восстановления зарядов
+	p.updateDashTimers()
 }
 
 // handleDash обрабатывает логику рывка
-func (p *Player) handleDash() {
+func (p *Player) handleDash(input Input) {
 	// Проверяем возможность рывка:
-	// 1. Нажата клавиша пробел
+	// 1. Нажата клавиша рывка
 	// 2. Игрок не выполняет рывок в данный момент
 	// 3. Игрок движется (есть направление)
 	// 4. Есть заряды рывка
-	if ebiten.IsKeyPressed(ebiten.KeySpace) && 
+	if input.Dash && 
 	   !p.Dashing && 
 	   (p.DirX != 0 || p.DirY != 0) && 
 	   p.DashCharges > 0 {
@@ -85,36 +80,44 @@ func (p *Player) handleDash() {
 		p.Dashing = true
 		// Уменьшаем количество зарядов
 		p.DashCharges--
+		p.DashesUsed++
 		// Запускаем восстановление заряда
-		go p.rechargeDash()
+		p.DashRecharge = append(p.DashRecharge, DashRechargeTime)
 		// Ограничиваем длительность рывка
-		go func() {
-			time.Sleep(200 * time.Millisecond)
-			p.Dashing = false
-		}()
+		p.DashTimer = DashDuration
 	}
 }
 
-// rechargeDash восстанавливает заряд рывка через указанное время
-func (p *Player) rechargeDash() {
-	// Ждем 5 секунд перед восстановлением заряда
-	time.Sleep(5 * time.Second)
-	// Восстанавливаем заряд если не достигнут максимум
-	if p.DashCharges < p.MaxDashes {
-		p.DashCharges++
+// updateDashTimers отсчитывает время рывка и восстановления зарядов
+func (p *Player) updateDashTimers() {
+	// Завершаем рывок по истечении его длительности
+	if p.Dashing {
+		p.DashTimer -= 1.0 / 60.0
+		if p.DashTimer <= 0 {
+			p.DashTimer = 0
+			p.Dashing = false
+		}
+	}
+	
+	// Восстанавливаем заряды, время которых истекло
+	remaining := p.DashRecharge[:0]
+	for _, t := range p.DashRecharge {
+		t -= 1.0 / 60.0
+		if t > 0 {
+			remaining = append(remaining, t)
+		} else if p.DashCharges < p.MaxDashes {
+			p.DashCharges++
+		}
 	}
+	p.DashRecharge = remaining
 }
 
-// DrawDashCharges отрисовывает индикаторы зарядов рывка
-func (p *Player) DrawDashCharges(screen *ebiten.Image) {
-	// Импорт нужно добавить в файл: "image/color"
-	// Отрисовка зарядов рывка
-	// import "image/color"
-	// for i := 0; i < p.DashCharges; i++ {
-	//    img := ebiten.NewImage(10, 5)
-	//    img.Fill(color.RGBA{255, 255, 0, 255})
-	//    opCharge := &ebiten.DrawImageOptions{}
-	//    opCharge.GeoM.Translate(p.X+float64(i*15), p.Y-15)
-	//    screen.DrawImage(img, opCharge)
-	// }
-}
\ No newline at end of file
+// DashProgress возвращает готовность восстанавливающихся зарядов рывка (0..1), начиная с ближайшего
+func (p *Player) DashProgress() []float64 {
+	progress := make([]float64, 0, len(p.DashRecharge))
+	for _, t := range p.DashRecharge {
+		progress = append(progress, 1-t/DashRechargeTime)
+	}
+	slices.SortFunc(progress, func(a, b float64) int { return cmp.Compare(b, a) })
+	return progress
+}
@@ -1,24 +1,25 @@
 package player
 
 import (
-	"image"
 	"fmt"
 	"log"
-	"os"
-	"time"
 	_ "image/png"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"superpupergame/animation"
+	"superpupergame/assets"
+	"superpupergame/atlas"
+	"superpupergame/config"
 	"superpupergame/debug" // Импортируем пакет debug
+	"superpupergame/text"
 	"superpupergame/utils"
+	"superpupergame/viewport"
 )
 
-// Константы для настройки спрайта и анимации
+// Константы для настройки спрайта
 const (
 	FrameWidth     = 32   // Ширина одного кадра в пикселях
 	FrameHeight    = 32   // Высота одного кадра в пикселях
-	FramesPerState = 4    // Количество кадров в одном состоянии анимации
 	ScaleFactor    = 3.0  // Коэффициент масштабирования спрайта
 )
 
@@ -46,35 +47,48 @@ type Player struct {
 	// Атрибуты атаки
 	AttackAngle    float64      // Угол атаки (в радианах)
 	AttackTimer    float64      // Таймер атаки
-	LastAttackTime time.Time    // Время последней атаки
+	AttackCooldown float64      // Задержка между атаками (в секундах)
+	AttackRecharge float64      // Оставшееся время до готовности атаки (в секундах)
 	
 	// Атрибуты рывка
 	DashSpeed      float64      // Скорость при рывке
 	DashCharges    int          // Текущее количество зарядов рывка
 	MaxDashes      int          // Максимальное количество зарядов рывка
+	DashTimer      float64      // Оставшееся время текущего рывка (в секундах)
+	DashRecharge   []float64    // Оставшееся время восстановления каждого потраченного заряда
+	DashesUsed     int          // Количество рывков за текущий забег
 	DirX, DirY     float64      // Компоненты вектора направления движения
 	
 	// Анимация
-	FrameX         int          // Текущий кадр по X (индекс в спрайт-листе)
-	FrameY         int          // Текущий кадр по Y (направление/состояние)
-	FrameCount     int          // Счётчик для анимации
+	Facing         int          // Направление взгляда (DirUp, DirDown, DirLeft, DirRight)
+	Animator       *animation.Animator // Клипы анимации из assets/player_sprites.json
 	DeathTimer     float64      // Таймер для анимации смерти
 	
 	// Ресурсы
-	SpriteSheet    *ebiten.Image // Спрайт-лист игрока
-	SwordImage     *ebiten.Image // Изображение меча
+	Sword          *atlas.Sprite // Спрайт меча в атласе
 	
 	// Система отладки
 	DebugSystem    *debug.Debug // Ссылка на систему отладки
+	
+	// Управление
+	Keys           *config.KeyBindings // Назначенные клавиши движения и рывка
+	MouseCaptured  bool         // Мышь занята отладочным инспектором, щелчок не атакует
+	input          Input        // Нажатия атаки и рывка, накопленные с прошлого тика
 }
 
 // NewPlayer создаёт и инициализирует нового игрока с указанными координатами
-func NewPlayer(x, y float64, debugSystem *debug.Debug) *Player {
-	// Загружаем изображение меча
-	sword := loadImage("assets/sword.png")
+func NewPlayer(x, y float64, debugSystem *debug.Debug, keys *config.KeyBindings, assetManager *assets.Manager) *Player {
+	// Загружаем изображение меча; при ошибке рисуется заглушка
+	sword, err := assetManager.Sprite("sword.png")
+	if err != nil {
+		log.Printf("Ошибка загрузки меча: %v", err)
+	}
 	
-	// Загружаем спрайт-лист игрока
-	spriteSheet := loadImage("assets/player_sprites.png")
+	// Загружаем клипы анимации игрока
+	sheet, err := assetManager.Sheet("player_sprites.json")
+	if err != nil {
+		log.Printf("Ошибка загрузки анимации игрока: %v", err)
+	}
 	
 	// Создаём и возвращаем новый экземпляр игрока
 	return &Player{
@@ -86,19 +100,29 @@ func NewPlayer(x, y float64, debugSystem *debug.Debug) *Player {
 		DirY:           0,                  // Начальное направление по Y
 		DashCharges:    2,                  // Начальное количество зарядов рывка
 		MaxDashes:      2,                  // Максимальное количество зарядов
-		LastAttackTime: time.Time{},        // Нулевое время последней атаки
-		SwordImage:     sword,              // Изображение меча
-		SpriteSheet:    spriteSheet,        // Спрайт-лист игрока
-		FrameX:         0,                  // Начальный кадр по X
-		FrameY:         0,                  // Начальное состояние: стояние вправо
+		AttackCooldown: 0.5,                // Задержка между атаками
+		Sword:          sword,              // Спрайт меча
+		Animator:       animation.NewAnimator(sheet, "idle_down"), // Аниматор игрока
+		Facing:         DirDown,            // Начальное направление: вниз
 		Health:         100,                // Начальное здоровье
 		MaxHealth:      100,                // Максимальное здоровье
 		Dying:          false,              // Флаг смерти
 		DeathTimer:     0,                  // Таймер смерти
 		DebugSystem:    debugSystem,        // Система отладки
+		Keys:           keys,               // Назначенные клавиши
 	}
 }
 
+// ResetStats возвращает характеристики игрока к начальным значениям,
+// отменяя улучшения, полученные за уровни
+func (p *Player) ResetStats() {
+	p.Speed = 2.0
+	p.DashSpeed = 5.0
+	p.MaxDashes = 2
+	p.MaxHealth = 100
+	p.AttackCooldown = 0.5
+}
+
 // Update обновляет состояние игрока на каждом кадре
 func (p *Player) Update() {
 	// Если игрок умирает, обновляем только анимацию смерти
@@ -107,18 +131,21 @@ func (p *Player) Update() {
 		return
 	}
 	
+	// Нажатия атаки и рывка, накопленные с прошлого тика
+	input := p.takeInput()
+	
 	// Обновляем движение игрока (перенесено в movement.go)
-	p.UpdateMovement()
+	p.UpdateMovement(input)
 	
 	// Обновляем атаку игрока (перенесено в combat.go)
-	p.UpdateCombat()
+	p.UpdateCombat(input)
 	
 	// Обновляем анимацию игрока (перенесено в animation.go)
 	p.UpdateAnimation()
 	
-	// Ограничиваем позицию игрока границами экрана
-	p.X = utils.Clamp(p.X, 0, 1260)
-	p.Y = utils.Clamp(p.Y, 0, 940)
+	// Ограничиваем позицию игрока границами арены
+	p.X = utils.Clamp(p.X, 0, viewport.ArenaWidth-20)
+	p.Y = utils.Clamp(p.Y, 0, viewport.ArenaHeight-20)
 }
 
 // Draw отрисовывает игрока на экране
@@ -126,41 +153,22 @@ func (p *Player) Draw(screen *ebiten.Image) {
     // Отрисовка спрайта игрока
     p.DrawSprite(screen)
 
-    // Отрисовка полосок заряда рывка
-    if !p.Dying {
-        p.DrawDashCharges(screen)
-        if p.Attacking {
-            p.DrawSword(screen)
-        }
+    // Заряды рывка и готовность атаки показывает HUD
+    if !p.Dying && p.Attacking {
+        p.DrawSword(screen)
     }
 
     // Отрисовка отладочной информации (без хитбокса)
     if p.DebugSystem.IsEnabled() && p.DebugSystem.ShowPositions {
         debugInfo := fmt.Sprintf("X: %.1f, Y: %.1f", p.X, p.Y)
-        ebitenutil.DebugPrintAt(screen, debugInfo, int(p.X), int(p.Y)-15)
+        text.Print(screen, debugInfo, int(p.X), int(p.Y)-15)
         healthInfo := fmt.Sprintf("HP: %.1f/%.1f", p.Health, p.MaxHealth)
-        ebitenutil.DebugPrintAt(screen, healthInfo, int(p.X), int(p.Y)-30)
+        text.Print(screen, healthInfo, int(p.X), int(p.Y)-30)
         dashInfo := fmt.Sprintf("Dash: %d/%d", p.DashCharges, p.MaxDashes)
-        ebitenutil.DebugPrintAt(screen, dashInfo, int(p.X), int(p.Y)-45)
+        text.Print(screen, dashInfo, int(p.X), int(p.Y)-45)
     }
 }
 
-// loadImage загружает изображение из файла и возвращает его как ebiten.Image
-func loadImage(path string) *ebiten.Image {
-	file, err := os.Open(path)
-	if err != nil {
-		log.Fatalf("Не удалось открыть файл %s: %v", path, err)
-	}
-	defer file.Close()
-	
-	img, _, err := image.Decode(file)
-	if err != nil {
-		log.Fatalf("Не удалось декодировать изображение %s: %v", path, err)
-	}
-	
-	return ebiten.NewImageFromImage(img)
-}
-
 // GetHitbox возвращает координаты и размеры хитбокса игрока
 func (p *Player) GetHitbox() (x, y, width, height float64) {
     hitboxWidth := 20.0 	// Ширина хитбокса
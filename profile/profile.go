@@ -0,0 +1,226 @@
+// Пакет profile хранит профиль игрока: таблицу рекордов и общую статистику
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"superpupergame/utils"
+)
+
+// fileName - имя файла профиля в каталоге настроек пользователя
+const fileName = "profile.json"
+
+// MaxHighScores - количество записей в таблице рекордов
+const MaxHighScores = 10
+
+// MaxNameLength - максимальная длина имени в таблице рекордов
+const MaxNameLength = 12
+
+// ModeNormal - обычный режим игры
+const ModeNormal = "normal"
+
+// SortBy задаёт порядок сортировки таблицы рекордов
+type SortBy int
+
+const (
+	SortByScore SortBy = iota // По счёту
+	SortByWave                // По достигнутой волне
+)
+
+// HighScore - одна запись в таблице рекордов
+type HighScore struct {
+	Name  string    `json:"name"`
+	Mode  string    `json:"mode"`
+	Score int       `json:"score"`
+	Wave  int       `json:"wave"`
+	Level int       `json:"level"`
+	Seed  uint64    `json:"seed"`
+	Date  time.Time `json:"date"`
+}
+
+// Stats - статистика за всё время игры
+type Stats struct {
+	Kills      int     `json:"kills"`       // Всего убито врагов
+	Coins      int     `json:"coins"`       // Всего собрано монеток
+	DashesUsed int     `json:"dashes_used"` // Всего сделано рывков
+	Deaths     int     `json:"deaths"`      // Количество смертей
+	PlayTime   float64 `json:"play_time"`   // Общее время игры (в секундах)
+}
+
+// Run - итоги одного завершённого забега
+type Run struct {
+	Mode       string
+	Score      int
+	Wave       int
+	Level      int
+	Seed       uint64
+	Kills      int
+	Coins      int
+	DashesUsed int
+	PlayTime   float64
+	Abandoned  bool // Забег брошен (перезапуск, выход без сохранения), а не закончился смертью
+}
+
+// Profile - профиль игрока
+type Profile struct {
+	HighScores []HighScore `json:"high_scores"`
+	Stats      Stats       `json:"stats"`
+	LastName   string      `json:"last_name"` // Последнее введённое имя для таблицы рекордов
+
+	// path - путь к файлу профиля; пустой, если каталог настроек недоступен
+	path string
+}
+
+// Load загружает профиль из каталога настроек пользователя.
+// Если файла нет, возвращается пустой профиль. Если файл повреждён,
+// он переименовывается в .bak, чтобы не потерять данные, и тоже возвращается пустой профиль.
+func Load() *Profile {
+	path, err := utils.ConfigPath(fileName)
+	if err != nil {
+		log.Printf("Профиль не будет сохраняться: %v", err)
+		return &Profile{}
+	}
+
+	p := &Profile{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p
+	}
+	if err != nil {
+		log.Printf("Не удалось прочитать профиль: %v", err)
+		return p
+	}
+
+	if err := json.Unmarshal(data, p); err != nil {
+		log.Printf("Профиль повреждён, создаём новый: %v", err)
+		if err := os.Rename(path, path+".bak"); err != nil {
+			log.Printf("Не удалось сохранить копию повреждённого профиля: %v", err)
+		}
+		return &Profile{path: path}
+	}
+	return p
+}
+
+// Save атомарно записывает профиль на диск
+func (p *Profile) Save() error {
+	if p.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать профиль: %w", err)
+	}
+	return utils.WriteFileAtomic(p.path, data)
+}
+
+// Best возвращает лучший счёт в таблице рекордов
+func (p *Profile) Best() int {
+	if len(p.HighScores) == 0 {
+		return 0
+	}
+	return p.HighScores[0].Score
+}
+
+// RecordRun добавляет итоги забега в статистику и таблицу рекордов;
+// брошенный забег попадает только в статистику.
+// Возвращает место забега в таблице (начиная с 1, 0 - не попал в таблицу)
+// и признак нового личного рекорда.
+func (p *Profile) RecordRun(run Run) (rank int, personalBest bool) {
+	p.Stats.Kills += run.Kills
+	p.Stats.Coins += run.Coins
+	p.Stats.DashesUsed += run.DashesUsed
+	p.Stats.PlayTime += run.PlayTime
+
+	// Брошенный забег пополняет только общую статистику: это не смерть и не рекорд
+	if run.Abandoned {
+		return 0, false
+	}
+	p.Stats.Deaths++
+
+	if run.Score <= 0 {
+		return 0, false
+	}
+	personalBest = run.Score > p.Best()
+
+	mode := run.Mode
+	if mode == "" {
+		mode = ModeNormal
+	}
+
+	entry := HighScore{
+		Mode:  mode,
+		Score: run.Score,
+		Wave:  run.Wave,
+		Level: run.Level,
+		Seed:  run.Seed,
+		Date:  time.Now(),
+	}
+
+	// Вставляем запись так, чтобы таблица осталась отсортированной по убыванию счёта
+	index := sort.Search(len(p.HighScores), func(i int) bool {
+		return p.HighScores[i].Score < entry.Score
+	})
+	if index >= MaxHighScores {
+		return 0, false
+	}
+	p.HighScores = append(p.HighScores, HighScore{})
+	copy(p.HighScores[index+1:], p.HighScores[index:])
+	p.HighScores[index] = entry
+	if len(p.HighScores) > MaxHighScores {
+		p.HighScores = p.HighScores[:MaxHighScores]
+	}
+
+	return index + 1, personalBest
+}
+
+// SetName задаёт имя для записи с указанным местом в таблице (начиная с 1)
+func (p *Profile) SetName(rank int, name string) {
+	if rank < 1 || rank > len(p.HighScores) {
+		return
+	}
+	if runes := []rune(name); len(runes) > MaxNameLength {
+		name = string(runes[:MaxNameLength])
+	}
+	p.HighScores[rank-1].Name = name
+	p.LastName = name
+}
+
+// Modes возвращает список режимов, встречающихся в таблице рекордов
+func (p *Profile) Modes() []string {
+	var modes []string
+	seen := make(map[string]bool)
+	for _, entry := range p.HighScores {
+		if !seen[entry.Mode] {
+			seen[entry.Mode] = true
+			modes = append(modes, entry.Mode)
+		}
+	}
+	sort.Strings(modes)
+	return modes
+}
+
+// Entries возвращает записи таблицы рекордов указанного режима (пустая строка - все режимы),
+// отсортированные в заданном порядке
+func (p *Profile) Entries(sortBy SortBy, mode string) []HighScore {
+	entries := make([]HighScore, 0, len(p.HighScores))
+	for _, entry := range p.HighScores {
+		if mode == "" || entry.Mode == mode {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if sortBy == SortByWave && entries[i].Wave != entries[j].Wave {
+			return entries[i].Wave > entries[j].Wave
+		}
+		return entries[i].Score > entries[j].Score
+	})
+	return entries
+}
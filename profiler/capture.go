@@ -0,0 +1,100 @@
+// Пакет profiler измеряет время частей кадра, выделения памяти и количество объектов
+package profiler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"superpupergame/console"
+	"superpupergame/utils"
+)
+
+// Capture возвращает записанные кадры в формате CSV: по строке на кадр от старого к новому,
+// столбцы - время кадра, выделения памяти, время каждой области (в миллисекундах) и количество объектов
+func Capture() []byte {
+	p := std
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"frame", "frame_ms", "allocs", "alloc_bytes"}
+	for _, name := range p.scopeOrder {
+		header = append(header, name+"_ms")
+	}
+	header = append(header, p.countOrder...)
+	w.Write(header)
+
+	first := p.recorded - p.window()
+	for k := range p.window() {
+		i := p.index(k)
+		f := p.frames[i]
+		record := []string{
+			strconv.Itoa(first + k),
+			strconv.FormatFloat(ms(f.duration), 'f', 3, 64),
+			strconv.FormatUint(f.allocs, 10),
+			strconv.FormatUint(f.bytes, 10),
+		}
+		for _, name := range p.scopeOrder {
+			record = append(record, strconv.FormatFloat(ms(time.Duration(p.scopes[name].samples[i])), 'f', 3, 64))
+		}
+		for _, name := range p.countOrder {
+			record = append(record, strconv.FormatInt(p.counts[name].samples[i], 10))
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+// Dump записывает записанные кадры в файл; пустой path - файл с датой в каталоге настроек.
+// Возвращает путь к записанному файлу.
+func Dump(path string) (string, error) {
+	if std.recorded == 0 {
+		return "", fmt.Errorf("нет записанных кадров, профилировщик выключен")
+	}
+	if path == "" {
+		var err error
+		path, err = utils.ConfigPath("profile-" + time.Now().Format("20060102-150405") + ".csv")
+		if err != nil {
+			return "", err
+		}
+	}
+	if err := utils.WriteFileAtomic(path, Capture()); err != nil {
+		return "", fmt.Errorf("не удалось записать профиль: %w", err)
+	}
+	return path, nil
+}
+
+func init() {
+	console.Register(console.Command{
+		Name:     "profile",
+		Usage:    "<on|off|dump> [file]",
+		Help:     "включить или выключить профилировщик, выгрузить последние кадры в CSV",
+		Complete: console.Choices(0, "on", "off", "dump"),
+		Run: func(c *console.Console, args []string) error {
+			if len(args) == 0 {
+				return console.ErrUsage
+			}
+			switch args[0] {
+			case "on", "off":
+				SetEnabled(args[0] == "on")
+				c.Printf("Профилировщик: %s", args[0])
+			case "dump":
+				file := ""
+				if len(args) > 1 {
+					file = args[1]
+				}
+				path, err := Dump(file)
+				if err != nil {
+					return err
+				}
+				c.Printf("Профиль записан: %s (кадров: %d)", path, std.window())
+			default:
+				return console.ErrUsage
+			}
+			return nil
+		},
+	})
+}
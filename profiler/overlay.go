@@ -0,0 +1,95 @@
+// Пакет profiler измеряет время частей кадра, выделения памяти и количество объектов
+package profiler
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/i18n"
+	"superpupergame/text"
+)
+
+// Размеры панели профилировщика
+const (
+	panelWidth  = 380                   // Ширина панели
+	graphHeight = 60                    // Высота графика времени кадра
+	rowHeight   = 16                    // Высота строки таблицы
+	graphScale  = 33 * time.Millisecond // Время кадра, которое занимает график по высоте
+)
+
+// frameBudget - время кадра при 60 кадрах в секунду
+const frameBudget = time.Second / 60
+
+// Draw отрисовывает панель профилировщика в правом нижнем углу экрана:
+// график времени кадра, таблицу областей, выделения памяти и количество объектов
+func Draw(screen *ebiten.Image) {
+	p := std
+	if !p.enabled {
+		return
+	}
+	style := text.Options{Font: text.Mono(), Size: text.SizeSmall}
+
+	rows := 3 + len(p.scopeOrder) + len(p.countOrder)
+	height := float64(graphHeight + 20 + rows*rowHeight)
+	bounds := screen.Bounds()
+	x := float64(bounds.Dx()) - panelWidth - 10
+	y := float64(bounds.Dy()) - height - 10
+	vector.DrawFilledRect(screen, float32(x), float32(y), panelWidth, float32(height), color.RGBA{0, 0, 0, 200}, false)
+
+	// График: столбик на кадр, красный - кадр не уложился в бюджет
+	gx, gy := float32(x+10), float32(y+10)
+	barWidth := float32(panelWidth-20) / HistorySize
+	for k := range p.window() {
+		d := p.frames[p.index(k)].duration
+		h := float32(min(1, float64(d)/float64(graphScale))) * graphHeight
+		clr := color.RGBA{80, 200, 120, 255}
+		if d > frameBudget+frameBudget/10 {
+			clr = color.RGBA{220, 60, 60, 255}
+		}
+		vector.DrawFilledRect(screen, gx+float32(k)*barWidth, gy+graphHeight-h, barWidth, h, clr, false)
+	}
+	budgetY := gy + graphHeight - float32(float64(frameBudget)/float64(graphScale))*graphHeight
+	vector.StrokeLine(screen, gx, budgetY, gx+panelWidth-20, budgetY, 1, color.RGBA{255, 255, 255, 120}, false)
+
+	row := y + graphHeight + 20
+	line := func(format string, args ...any) {
+		text.Draw(screen, fmt.Sprintf(format, args...), x+10, row, style)
+		row += rowHeight
+	}
+
+	var frameAvg, framePeak time.Duration
+	var allocAvg, allocPeak, bytesAvg uint64
+	n := p.window()
+	for k := range n {
+		f := p.frames[p.index(k)]
+		frameAvg += f.duration
+		framePeak = max(framePeak, f.duration)
+		allocAvg += f.allocs
+		allocPeak = max(allocPeak, f.allocs)
+		bytesAvg += f.bytes
+	}
+	if n > 0 {
+		frameAvg /= time.Duration(n)
+		allocAvg /= uint64(n)
+		bytesAvg /= uint64(n)
+	}
+
+	line("%-14s %8s %8s", i18n.T("profiler.scope"), i18n.T("profiler.avg"), i18n.T("profiler.max"))
+	line("%-14s %8.2f %8.2f", i18n.T("profiler.frame"), ms(frameAvg), ms(framePeak))
+	for _, name := range p.scopeOrder {
+		avg, peak := p.stats(p.scopes[name])
+		line("%-14s %8.2f %8.2f", name, ms(time.Duration(avg)), ms(time.Duration(peak)))
+	}
+	line(i18n.T("profiler.allocs"), allocAvg, allocPeak, float64(bytesAvg)/1024, float64(p.mem.HeapAlloc)/(1024*1024))
+	for _, name := range p.countOrder {
+		line("%-14s %8d", name, p.counts[name].current)
+	}
+}
+
+// ms переводит длительность в миллисекунды
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,191 @@
+// Пакет profiler измеряет время частей кадра, выделения памяти и количество объектов.
+//
+// Части кадра оборачиваются в именованные области:
+//
+//	t := profiler.Begin("player")
+//	p.player.Update()
+//	t.End()
+//
+// Пока профилировщик выключен, Begin и End только проверяют флаг и ничего не выделяют.
+package profiler
+
+import (
+	"runtime"
+	"time"
+)
+
+// HistorySize - сколько последних кадров хранится для графика, средних и выгрузки
+const HistorySize = 240
+
+// Timer - открытая область измерения; нулевой Timer ничего не измеряет
+type Timer struct {
+	name  string
+	start time.Time
+}
+
+// series - значения одной величины за последние кадры
+type series struct {
+	// current - значение, накопленное в текущем кадре
+	current int64
+
+	// samples - значения за последние кадры по кругу
+	samples [HistorySize]int64
+}
+
+// frame - итоги одного кадра
+type frame struct {
+	duration time.Duration // Время от начала кадра до начала следующего
+	allocs   uint64        // Выделений памяти за кадр
+	bytes    uint64        // Выделено байт за кадр
+}
+
+// Profiler - накопленные измерения
+type Profiler struct {
+	// enabled - идут ли измерения
+	enabled bool
+
+	// scopes, counts - время областей (в наносекундах) и количество объектов по имени
+	scopes, counts map[string]*series
+
+	// scopeOrder, countOrder - имена в порядке первого появления, чтобы строки не прыгали
+	scopeOrder, countOrder []string
+
+	// frames - итоги последних кадров по кругу
+	frames [HistorySize]frame
+
+	// recorded - сколько кадров записано с включения
+	recorded int
+
+	// frameStart - начало текущего кадра; нулевое, пока не начат первый кадр
+	frameStart time.Time
+
+	// mem - последние прочитанные счётчики памяти
+	mem runtime.MemStats
+}
+
+// std - профилировщик игры
+var std = &Profiler{
+	scopes: make(map[string]*series),
+	counts: make(map[string]*series),
+}
+
+// Enabled сообщает, идут ли измерения
+func Enabled() bool {
+	return std.enabled
+}
+
+// SetEnabled включает или выключает измерения; при включении история начинается заново
+func SetEnabled(enabled bool) {
+	if enabled == std.enabled {
+		return
+	}
+	std.enabled = enabled
+	if enabled {
+		std.reset()
+	}
+}
+
+// reset очищает историю
+func (p *Profiler) reset() {
+	p.recorded = 0
+	p.frameStart = time.Time{}
+	p.frames = [HistorySize]frame{}
+	for _, s := range p.scopes {
+		*s = series{}
+	}
+	for _, s := range p.counts {
+		*s = series{}
+	}
+	runtime.ReadMemStats(&p.mem)
+}
+
+// Begin открывает область измерения с указанным именем
+func Begin(name string) Timer {
+	if !std.enabled {
+		return Timer{}
+	}
+	return Timer{name: name, start: time.Now()}
+}
+
+// End закрывает область и прибавляет её время к текущему кадру;
+// одна область может открываться за кадр несколько раз
+func (t Timer) End() {
+	if t.name == "" || !std.enabled {
+		return
+	}
+	std.series(&std.scopes, &std.scopeOrder, t.name).current += int64(time.Since(t.start))
+}
+
+// Count запоминает количество объектов в текущем кадре
+func Count(name string, n int) {
+	if !std.enabled {
+		return
+	}
+	std.series(&std.counts, &std.countOrder, name).current = int64(n)
+}
+
+// series возвращает ряд по имени, создавая его при первом обращении
+func (p *Profiler) series(m *map[string]*series, order *[]string, name string) *series {
+	s, ok := (*m)[name]
+	if !ok {
+		s = &series{}
+		(*m)[name] = s
+		*order = append(*order, name)
+	}
+	return s
+}
+
+// FrameStart завершает предыдущий кадр и начинает новый; вызывается в начале каждого Update
+func FrameStart() {
+	p := std
+	if !p.enabled {
+		return
+	}
+	now := time.Now()
+	if !p.frameStart.IsZero() {
+		mallocs, total := p.mem.Mallocs, p.mem.TotalAlloc
+		runtime.ReadMemStats(&p.mem)
+
+		i := p.recorded % HistorySize
+		p.frames[i] = frame{
+			duration: now.Sub(p.frameStart),
+			allocs:   p.mem.Mallocs - mallocs,
+			bytes:    p.mem.TotalAlloc - total,
+		}
+		for _, s := range p.scopes {
+			s.samples[i] = s.current
+			s.current = 0
+		}
+		// Количество объектов остаётся прежним, пока его не обновят
+		for _, s := range p.counts {
+			s.samples[i] = s.current
+		}
+		p.recorded++
+	}
+	p.frameStart = now
+}
+
+// window возвращает количество записанных кадров в истории
+func (p *Profiler) window() int {
+	return min(p.recorded, HistorySize)
+}
+
+// index возвращает позицию k-го кадра истории, считая от самого старого
+func (p *Profiler) index(k int) int {
+	return (p.recorded - p.window() + k) % HistorySize
+}
+
+// stats возвращает среднее и наибольшее значение ряда за записанные кадры
+func (p *Profiler) stats(s *series) (avg, peak int64) {
+	n := p.window()
+	if n == 0 {
+		return 0, 0
+	}
+	var sum int64
+	for k := range n {
+		v := s.samples[p.index(k)]
+		sum += v
+		peak = max(peak, v)
+	}
+	return sum / int64(n), peak
+}
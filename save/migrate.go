@@ -0,0 +1,79 @@
+package save
+
+import (
+	"fmt"
+)
+
+// Migration обновляет разобранные данные сохранения на одну версию вперёд
+type Migration func(data map[string]any) error
+
+// migrations - миграции, индексированные по версии, из которой они обновляют.
+// При изменении формата увеличьте CurrentVersion и зарегистрируйте
+// миграцию со старой версии через RegisterMigration.
+var migrations = map[int]Migration{
+	1: migrateFrameYToFacing,
+	2: migrateAddCombo,
+	3: migrateAddWaveBreak,
+}
+
+// RegisterMigration регистрирует миграцию с версии from на версию from+1
+func RegisterMigration(from int, m Migration) {
+	migrations[from] = m
+}
+
+// migrate последовательно применяет миграции, пока данные не достигнут текущей версии
+func migrate(data map[string]any, version int) error {
+	if version > CurrentVersion {
+		return fmt.Errorf("%w: %d (поддерживается до %d)", ErrUnsupportedVersion, version, CurrentVersion)
+	}
+	if version < 1 {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	for v := version; v < CurrentVersion; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return fmt.Errorf("%w: нет миграции с версии %d", ErrUnsupportedVersion, v)
+		}
+		if err := m(data); err != nil {
+			return fmt.Errorf("ошибка миграции сохранения с версии %d: %w", v, err)
+		}
+		data["version"] = float64(v + 1)
+	}
+	return nil
+}
+
+// migrateFrameYToFacing заменяет строку спрайт-листа игрока (frame_y) направлением взгляда (facing).
+// В версии 1 строка 2 означала взгляд вверх, строка 4 - вбок, остальные - вниз.
+// Значения facing соответствуют player.DirUp (1), player.DirDown (2) и player.DirRight (4).
+func migrateFrameYToFacing(data map[string]any) error {
+	player, ok := data["player"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("нет данных игрока")
+	}
+
+	frameY, _ := player["frame_y"].(float64)
+	switch frameY {
+	case 2:
+		player["facing"] = float64(1)
+	case 4:
+		player["facing"] = float64(4)
+	default:
+		player["facing"] = float64(2)
+	}
+	delete(player, "frame_y")
+	return nil
+}
+
+// migrateAddCombo добавляет серию убийств, которой не было в версии 2; забег продолжается без серии
+func migrateAddCombo(data map[string]any) error {
+	data["combo"] = float64(0)
+	data["combo_timer"] = float64(0)
+	return nil
+}
+
+// migrateAddWaveBreak добавляет паузу между волнами, которой не было в версии 3; пауза начинается заново
+func migrateAddWaveBreak(data map[string]any) error {
+	data["wave_break"] = float64(0)
+	return nil
+}
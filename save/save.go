@@ -0,0 +1,205 @@
+// Пакет save отвечает за сохранение и загрузку незавершённого забега
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"superpupergame/utils"
+)
+
+// CurrentVersion - текущая версия формата файла сохранения
+const CurrentVersion = 4
+
+// fileName - имя файла сохранения в каталоге настроек пользователя
+const fileName = "savegame.json"
+
+// ErrNoSave возвращается, когда файла сохранения нет
+var ErrNoSave = errors.New("сохранение не найдено")
+
+// ErrUnsupportedVersion возвращается для файлов из более новой версии игры
+var ErrUnsupportedVersion = errors.New("неподдерживаемая версия сохранения")
+
+// PlayerData - сохраняемые поля игрока
+type PlayerData struct {
+	X                  float64   `json:"x"`
+	Y                  float64   `json:"y"`
+	Health             float64   `json:"health"`
+	MaxHealth          float64   `json:"max_health"`
+	Speed              float64   `json:"speed"`
+	DashSpeed          float64   `json:"dash_speed"`
+	DashCharges        int       `json:"dash_charges"`
+	MaxDashes          int       `json:"max_dashes"`
+	Dashing            bool      `json:"dashing"`
+	DashTimer          float64   `json:"dash_timer"`
+	DashRecharge       []float64 `json:"dash_recharge"`
+	Attacking          bool      `json:"attacking"`
+	AttackTimer        float64   `json:"attack_timer"`
+	AttackAngle        float64   `json:"attack_angle"`
+	AttackCooldown     float64   `json:"attack_cooldown"`      // Задержка между атаками (в секундах)
+	AttackCooldownLeft float64   `json:"attack_cooldown_left"` // Остаток текущего кулдауна (в секундах)
+	Facing             int       `json:"facing"` // Направление взгляда (константы Dir* пакета player)
+}
+
+// EnemyData - сохраняемые поля врага
+type EnemyData struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Speed float64 `json:"speed"`
+	Alive bool    `json:"alive"`
+}
+
+// CoinData - сохраняемые поля монетки
+type CoinData struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Frame int     `json:"frame"`
+}
+
+// OrbData - сохраняемые поля сферы опыта
+type OrbData struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Value int     `json:"value"`
+}
+
+// Snapshot - полный снимок состояния забега
+type Snapshot struct {
+	Version         int         `json:"version"`
+	Seed            uint64      `json:"seed"`
+	RNG             []byte      `json:"rng"` // Внутреннее состояние генератора случайных чисел
+	Score           int         `json:"score"`
+	Wave            int         `json:"wave"`
+	Level           int         `json:"level"`
+	XP              int         `json:"xp"`
+	PendingLevelUps int         `json:"pending_level_ups"`
+	Player          PlayerData  `json:"player"`
+	Enemies         []EnemyData `json:"enemies"`
+	Coins           []CoinData  `json:"coins"`
+	XPOrbs          []OrbData   `json:"xp_orbs"`
+	CoinRespawns    []float64   `json:"coin_respawns"` // Оставшееся время до появления отложенных монеток
+	WaveBreak       float64     `json:"wave_break"`    // Сколько секунд прошло с уничтожения последнего врага волны
+	Kills           int         `json:"kills"`
+	CoinsCollected  int         `json:"coins_collected"`
+	DashesUsed      int         `json:"dashes_used"`
+	Elapsed         float64     `json:"elapsed"`     // Время забега (в секундах)
+	Combo           int         `json:"combo"`       // Убийства в текущей серии
+	ComboTimer      float64     `json:"combo_timer"` // Сколько секунд ещё продолжается серия
+
+	// Upgrades - сколько раз взято каждое улучшение; в старых сохранениях отсутствует
+	Upgrades map[string]int `json:"upgrades,omitempty"`
+}
+
+// Path возвращает путь к файлу сохранения в каталоге настроек пользователя
+func Path() (string, error) {
+	return utils.ConfigPath(fileName)
+}
+
+// Exists сообщает, есть ли сохранённый забег
+func Exists() bool {
+	path, err := Path()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Save записывает снимок в файл сохранения.
+// Файл сначала пишется во временный и затем переименовывается,
+// поэтому сбой во время записи не портит предыдущее сохранение.
+func Save(snapshot *Snapshot) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	snapshot.Version = CurrentVersion
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать сохранение: %w", err)
+	}
+
+	return utils.WriteFileAtomic(path, data)
+}
+
+// Load читает файл сохранения, при необходимости обновляя его до текущей версии
+func Load() (*Snapshot, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSave
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать сохранение: %w", err)
+	}
+
+	return decode(data)
+}
+
+// Remove удаляет файл сохранения, если он есть
+func Remove() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("не удалось удалить сохранение: %w", err)
+	}
+	return nil
+}
+
+// decode разбирает данные сохранения, применяя миграции старых версий
+func decode(data []byte) (*Snapshot, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("повреждённый файл сохранения: %w", err)
+	}
+
+	version, ok := raw["version"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("в сохранении отсутствует номер версии")
+	}
+	if err := migrate(raw, int(version)); err != nil {
+		return nil, err
+	}
+
+	// Повторно кодируем обновлённые данные и разбираем их в структуру
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось обновить сохранение: %w", err)
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("повреждённый файл сохранения: %w", err)
+	}
+	if err := snapshot.validate(); err != nil {
+		return nil, fmt.Errorf("повреждённый файл сохранения: %w", err)
+	}
+	return snapshot, nil
+}
+
+// validate проверяет, что значения снимка допустимы для продолжения забега
+func (s *Snapshot) validate() error {
+	switch {
+	case s.Wave < 1:
+		return fmt.Errorf("недопустимый номер волны %d", s.Wave)
+	case s.Level < 1:
+		return fmt.Errorf("недопустимый уровень %d", s.Level)
+	case s.Player.MaxHealth <= 0 || s.Player.Health <= 0:
+		return fmt.Errorf("недопустимое здоровье %.1f/%.1f", s.Player.Health, s.Player.MaxHealth)
+	case s.Player.MaxDashes < 0 || s.Player.DashCharges < 0:
+		return fmt.Errorf("недопустимое количество рывков %d/%d", s.Player.DashCharges, s.Player.MaxDashes)
+	case s.WaveBreak < 0:
+		return fmt.Errorf("недопустимая пауза между волнами %.2f", s.WaveBreak)
+	case s.Combo < 0 || s.ComboTimer < 0:
+		return fmt.Errorf("недопустимая серия убийств %d (%.2f с)", s.Combo, s.ComboTimer)
+	}
+	return nil
+}
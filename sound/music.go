@@ -0,0 +1,84 @@
+package sound
+
+import (
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// crossfadeDuration - длительность перехода между треками (в секундах)
+const crossfadeDuration = 1.5
+
+// track - звучащий музыкальный трек
+type track struct {
+	name   string
+	player *audio.Player
+	// fade - текущая громкость трека относительно шины музыки (0..1)
+	fade float64
+}
+
+// musicPlayer воспроизводит зацикленную музыку и плавно переходит между треками
+type musicPlayer struct {
+	manager *Manager
+
+	// current - трек, который звучит или нарастает
+	current *track
+
+	// fading - треки, которые затухают
+	fading []*track
+}
+
+// newMusicPlayer создаёт проигрыватель музыки
+func newMusicPlayer(manager *Manager) *musicPlayer {
+	return &musicPlayer{manager: manager}
+}
+
+// play начинает плавный переход на трек name
+func (mp *musicPlayer) play(name string) {
+	if mp.current != nil && mp.current.name == name {
+		return
+	}
+
+	if mp.current != nil {
+		mp.fading = append(mp.fading, mp.current)
+		mp.current = nil
+	}
+
+	// Трек декодируется по мере воспроизведения и зацикливается
+	src, length, err := mp.manager.decode(name)
+	if err != nil {
+		log.Printf("Музыка %s не загружена: %v", name, err)
+		return
+	}
+	player, err := mp.manager.context.NewPlayerF32(audio.NewInfiniteLoopF32(src, length))
+	if err != nil {
+		log.Printf("Не удалось воспроизвести музыку %s: %v", name, err)
+		return
+	}
+	player.SetVolume(0)
+	player.Play()
+	mp.current = &track{name: name, player: player}
+}
+
+// update изменяет громкость нарастающего и затухающих треков
+func (mp *musicPlayer) update() {
+	step := 1.0 / 60.0 / crossfadeDuration
+	volume := mp.manager.musicVolume()
+
+	if mp.current != nil {
+		mp.current.fade = min(1, mp.current.fade+step)
+		mp.current.player.SetVolume(volume * mp.current.fade)
+	}
+
+	remaining := mp.fading[:0]
+	for _, t := range mp.fading {
+		t.fade -= step
+		if t.fade <= 0 {
+			t.player.Close()
+			continue
+		}
+		t.player.SetVolume(volume * t.fade)
+		remaining = append(remaining, t)
+	}
+	mp.fading = remaining
+}
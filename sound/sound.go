@@ -0,0 +1,241 @@
+// Пакет sound отвечает за звуковые эффекты и музыку
+package sound
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"superpupergame/config"
+)
+
+// SampleRate - частота дискретизации звукового контекста
+const SampleRate = 44100
+
+// Имена звуковых эффектов
+const (
+	Swing      = "swing"       // Взмах меча
+	Hit        = "hit"         // Попадание по врагу
+	EnemyDeath = "enemy_death" // Смерть врага
+	Coin       = "coin"        // Сбор монетки
+	Dash       = "dash"        // Рывок
+	Hurt       = "hurt"        // Игрок получил урон
+	GameOver   = "game_over"   // Конец игры
+)
+
+// Имена музыкальных треков
+const (
+	MusicMenu  = "music_menu"
+	MusicPlay  = "music_play"
+	MusicDeath = "music_death"
+)
+
+// extensions - поддерживаемые форматы в порядке поиска файла
+var extensions = []string{".ogg", ".wav", ".mp3"}
+
+// stream - декодированный поток в формате float32 стерео
+type stream interface {
+	io.ReadSeeker
+	Length() int64
+	SampleRate() int
+}
+
+// Sound - звуковой эффект, полностью декодированный в память
+type Sound struct {
+	// data - PCM-данные в формате float32 стерео с частотой SampleRate
+	data []byte
+
+	// MaxVoices - сколько копий звука может звучать одновременно
+	MaxVoices int
+
+	// PitchVariance - случайное отклонение высоты тона (0.1 - до ±10%)
+	PitchVariance float64
+
+	// voices - звучащие сейчас копии
+	voices []*audio.Player
+}
+
+// Manager владеет единственным звуковым контекстом игры,
+// загружает звуки и управляет громкостью шин
+type Manager struct {
+	// context - звуковой контекст; в процессе может быть только один
+	context *audio.Context
+
+	// settings - настройки с громкостью шин
+	settings *config.Settings
+
+	// ReadFile читает файл ресурса; по умолчанию читает с диска
+	ReadFile func(path string) ([]byte, error)
+
+	// Dir - каталог со звуковыми ресурсами
+	Dir string
+
+	// sounds - загруженные звуковые эффекты
+	sounds map[string]*Sound
+
+	// music - проигрыватель музыки с плавными переходами
+	music *musicPlayer
+}
+
+// NewManager создаёт звуковой контекст и менеджер звука.
+// Громкость шин читается из settings на каждом кадре, поэтому изменения в настройках применяются сразу.
+func NewManager(settings *config.Settings) *Manager {
+	m := &Manager{
+		context:  audio.NewContext(SampleRate),
+		settings: settings,
+		ReadFile: os.ReadFile,
+		Dir:      "assets/audio",
+		sounds:   make(map[string]*Sound),
+	}
+	m.music = newMusicPlayer(m)
+	return m
+}
+
+// LoadDefaults загружает все звуки игры. Отсутствующие файлы не мешают игре, а только записываются в журнал.
+func (m *Manager) LoadDefaults() {
+	for _, name := range []string{Swing, Hit, EnemyDeath, Coin, Dash, Hurt, GameOver} {
+		if err := m.Load(name, 4, 0.08); err != nil {
+			log.Printf("Звук %s не загружен: %v", name, err)
+		}
+	}
+	// Звук конца игры всегда один и звучит без искажений
+	if s, ok := m.sounds[GameOver]; ok {
+		s.MaxVoices = 1
+		s.PitchVariance = 0
+	}
+}
+
+// Load загружает звуковой эффект с указанным ограничением полифонии и разбросом высоты тона
+func (m *Manager) Load(name string, maxVoices int, pitchVariance float64) error {
+	s, _, err := m.decode(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(s)
+	if err != nil {
+		return fmt.Errorf("не удалось декодировать %s: %w", name, err)
+	}
+
+	m.sounds[name] = &Sound{
+		data:          data,
+		MaxVoices:     maxVoices,
+		PitchVariance: pitchVariance,
+	}
+	return nil
+}
+
+// Play воспроизводит звуковой эффект. Если звук не загружен, ничего не происходит.
+func (m *Manager) Play(name string) {
+	s, ok := m.sounds[name]
+	if !ok {
+		return
+	}
+
+	// При превышении полифонии обрываем самую старую копию
+	if len(s.voices) >= s.MaxVoices && len(s.voices) > 0 {
+		s.voices[0].Close()
+		s.voices = s.voices[1:]
+	}
+
+	// Высота тона меняется пересчётом частоты дискретизации
+	var src io.ReadSeeker = bytes.NewReader(s.data)
+	if s.PitchVariance > 0 {
+		pitch := 1 + (rand.Float64()*2-1)*s.PitchVariance
+		src = audio.ResampleF32(src, int64(len(s.data)), int(SampleRate*pitch), SampleRate)
+	}
+
+	player, err := m.context.NewPlayerF32(src)
+	if err != nil {
+		log.Printf("Не удалось воспроизвести звук %s: %v", name, err)
+		return
+	}
+	player.SetVolume(m.sfxVolume())
+	player.Play()
+	s.voices = append(s.voices, player)
+}
+
+// PlayMusic плавно переключает музыку на указанный трек
+func (m *Manager) PlayMusic(name string) {
+	m.music.play(name)
+}
+
+// Update удаляет отзвучавшие копии эффектов и обновляет громкость и переходы музыки.
+// Вызывается один раз за кадр.
+func (m *Manager) Update() {
+	for _, s := range m.sounds {
+		playing := s.voices[:0]
+		for _, voice := range s.voices {
+			if voice.IsPlaying() {
+				playing = append(playing, voice)
+			} else {
+				voice.Close()
+			}
+		}
+		s.voices = playing
+	}
+
+	m.music.update()
+}
+
+// sfxVolume возвращает итоговую громкость шины эффектов
+func (m *Manager) sfxVolume() float64 {
+	return m.settings.MasterVolume * m.settings.SFXVolume
+}
+
+// musicVolume возвращает итоговую громкость шины музыки
+func (m *Manager) musicVolume() float64 {
+	return m.settings.MasterVolume * m.settings.MusicVolume
+}
+
+// decode находит файл звука в одном из поддерживаемых форматов и декодирует его.
+// Возвращает поток и его длину в байтах.
+func (m *Manager) decode(name string) (io.ReadSeeker, int64, error) {
+	for _, ext := range extensions {
+		data, err := m.ReadFile(filepath.Join(m.Dir, name+ext))
+		if err != nil {
+			continue
+		}
+		return decodeData(name+ext, data)
+	}
+	return nil, 0, fmt.Errorf("файл не найден в %s (ожидается %s)", m.Dir, strings.Join(extensions, ", "))
+}
+
+// decodeData декодирует данные по расширению файла и приводит их к частоте SampleRate
+func decodeData(name string, data []byte) (io.ReadSeeker, int64, error) {
+	var (
+		s   stream
+		err error
+	)
+	switch filepath.Ext(name) {
+	case ".ogg":
+		s, err = vorbis.DecodeF32(bytes.NewReader(data))
+	case ".wav":
+		s, err = wav.DecodeF32(bytes.NewReader(data))
+	case ".mp3":
+		s, err = mp3.DecodeF32(bytes.NewReader(data))
+	default:
+		return nil, 0, fmt.Errorf("неподдерживаемый формат %s", name)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("не удалось декодировать %s: %w", name, err)
+	}
+
+	if s.SampleRate() == SampleRate {
+		return s, s.Length(), nil
+	}
+
+	// Длина после пересчёта частоты, выровненная по кадру (2 канала по 4 байта)
+	length := s.Length() * SampleRate / int64(s.SampleRate())
+	length -= length % 8
+	return audio.ResampleF32(s, s.Length(), s.SampleRate(), SampleRate), length, nil
+}
@@ -0,0 +1,299 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"superpupergame/console"
+	"superpupergame/enemy"
+	"superpupergame/game"
+	"superpupergame/viewport"
+)
+
+// Пределы скорости симуляции для команды timescale
+const (
+	minTimeScale = 0.1
+	maxTimeScale = 4.0
+)
+
+// maxCommandCount - наибольшее n для команд spawn и step, чтобы опечатка не подвесила игру
+const maxCommandCount = 1000
+
+// errNoRun возвращается командами, которым нужен идущий забег
+var errNoRun = errors.New("забег не идёт")
+
+// Состояния, которые открывает команда state, по способу открытия
+var (
+	// baseStates заменяют весь стек состояний
+	baseStates = []string{StateMenu, StatePlaying, StateDeath}
+
+	// screenStates открываются поверх текущего состояния и сами закрываются, возвращая к нему
+	screenStates = []string{StateLeaderboard, StateStats, StateSettings}
+
+	// runOverlays открываются только поверх идущего забега
+	runOverlays = []string{StatePaused, StateLevelUp}
+)
+
+// registerCommands регистрирует команду консоли state
+func (sm *StateMachine) registerCommands() {
+	console.Register(console.Command{
+		Name:  "state",
+		Usage: "<name>",
+		Help:  "перейти в основное состояние или открыть экран поверх текущего",
+		Complete: func(args []string) []string {
+			if len(args) != 1 {
+				return nil
+			}
+			names := slices.Concat(baseStates, screenStates, runOverlays)
+			slices.Sort(names)
+			return names
+		},
+		Run: func(c *console.Console, args []string) error {
+			if len(args) != 1 {
+				return console.ErrUsage
+			}
+			if err := sm.openFromConsole(args[0]); err != nil {
+				return err
+			}
+			c.Printf("Состояние: %s", args[0])
+			return nil
+		},
+	})
+}
+
+// openFromConsole открывает состояние по команде state так, чтобы из него можно было выйти:
+// экраны, закрывающиеся через Pop, кладутся поверх текущего, а не остаются одни в стеке
+func (sm *StateMachine) openFromConsole(name string) error {
+	switch {
+	case slices.Contains(baseStates, name):
+		return sm.ChangeState(name, nil)
+	case slices.Contains(screenStates, name):
+		return sm.Push(name, nil)
+	case slices.Contains(runOverlays, name):
+		play, ok := sm.current().(*PlayState)
+		if !ok || !play.InProgress() {
+			return fmt.Errorf("%q открывается только поверх идущего забега", name)
+		}
+		// Окно выбора закрывается, когда выборы кончаются; без выбора счётчик ушёл бы в минус
+		if name == StateLevelUp {
+			play.pendingLevelUps = max(play.pendingLevelUps, 1)
+		}
+		return sm.Push(name, nil)
+	default:
+		return fmt.Errorf("%w: неизвестное состояние %q", console.ErrUsage, name)
+	}
+}
+
+// registerCommands регистрирует команды консоли, изменяющие забег
+func (p *PlayState) registerCommands() {
+	console.Register(console.Command{
+		Name: "god",
+		Help: "включить или выключить бессмертие",
+		Run: func(c *console.Console, args []string) error {
+			p.god = !p.god
+			c.Printf("Бессмертие: %v", p.god)
+			return nil
+		},
+	})
+	console.Register(console.Command{
+		Name:     "spawn",
+		Usage:    "<enemy|coin> <n>",
+		Help:     "создать n врагов на краях арены или n монеток",
+		Complete: console.Choices(0, "enemy", "coin"),
+		Run:      p.spawnCommand,
+	})
+	console.Register(console.Command{
+		Name:  "wave",
+		Usage: "<n>",
+		Help:  "заменить врагов волной с номером n",
+		Run: func(c *console.Console, args []string) error {
+			if err := p.requireRun(); err != nil {
+				return err
+			}
+			n, err := console.Int(args, 0, "n")
+			if err != nil {
+				return err
+			}
+			if n < 1 {
+				return fmt.Errorf("%w: номер волны начинается с 1", console.ErrUsage)
+			}
+			p.enemyCount = n
+			p.spawnWave()
+			c.Printf("Волна %d: врагов %d", n, len(p.enemies))
+			return nil
+		},
+	})
+	console.Register(console.Command{
+		Name:     "give",
+		Usage:    "<coins|xp> <n>",
+		Help:     "начислить n монеток (со счётом) или n опыта",
+		Complete: console.Choices(0, "coins", "xp"),
+		Run:      p.giveCommand,
+	})
+	console.Register(console.Command{
+		Name: "heal",
+		Help: "восстановить здоровье полностью",
+		Run: func(c *console.Console, args []string) error {
+			if err := p.requireRun(); err != nil {
+				return err
+			}
+			p.player.Health = p.player.MaxHealth
+			c.Printf("Здоровье: %.0f", p.player.Health)
+			return nil
+		},
+	})
+	console.Register(console.Command{
+		Name:     "kill",
+		Usage:    "all",
+		Help:     "убить всех врагов без награды",
+		Complete: console.Choices(0, "all"),
+		Run: func(c *console.Console, args []string) error {
+			if err := p.requireRun(); err != nil {
+				return err
+			}
+			if len(args) != 1 || args[0] != "all" {
+				return console.ErrUsage
+			}
+			killed := 0
+			for _, e := range p.enemies {
+				if e.Alive {
+					e.Alive = false
+					p.particles.Burst("enemy_death", e.X+10, e.Y+10)
+					killed++
+				}
+			}
+			c.Printf("Убито врагов: %d", killed)
+			return nil
+		},
+	})
+	console.Register(console.Command{
+		Name:  "timescale",
+		Usage: "<x>",
+		Help:  fmt.Sprintf("скорость симуляции от %g до %g", minTimeScale, maxTimeScale),
+		Run: func(c *console.Console, args []string) error {
+			x, err := console.Float(args, 0, "x")
+			if err != nil {
+				return err
+			}
+			if x < minTimeScale || x > maxTimeScale {
+				return fmt.Errorf("%w: x должен быть от %g до %g", console.ErrUsage, minTimeScale, maxTimeScale)
+			}
+			p.timeScale = x
+			c.Printf("Скорость симуляции: %gx", x)
+			return nil
+		},
+	})
+	console.Register(console.Command{
+		Name: "pause",
+		Help: "остановить или продолжить симуляцию; отрисовка не останавливается",
+		Run: func(c *console.Console, args []string) error {
+			p.simPaused = !p.simPaused
+			p.pendingSteps = 0
+			c.Printf("Симуляция остановлена: %v", p.simPaused)
+			return nil
+		},
+	})
+	console.Register(console.Command{
+		Name:  "step",
+		Usage: "[n]",
+		Help:  "остановить симуляцию и сделать n тиков (по умолчанию 1)",
+		Run: func(c *console.Console, args []string) error {
+			n := 1
+			if len(args) > 0 {
+				var err error
+				if n, err = console.Int(args, 0, "n"); err != nil {
+					return err
+				}
+			}
+			if n < 1 || n > maxCommandCount {
+				return fmt.Errorf("%w: n должен быть от 1 до %d", console.ErrUsage, maxCommandCount)
+			}
+			p.stepSimulation(n)
+			c.Printf("Тиков: %d", n)
+			return nil
+		},
+	})
+	console.Register(console.Command{
+		Name: "seed",
+		Help: "показать зерно текущего забега",
+		Run: func(c *console.Console, args []string) error {
+			c.Printf("Зерно: %d", p.seed)
+			return nil
+		},
+	})
+}
+
+// requireRun возвращает ошибку, если забег не идёт
+func (p *PlayState) requireRun() error {
+	if !p.InProgress() {
+		return errNoRun
+	}
+	return nil
+}
+
+// spawnCommand создаёт врагов или монетки; лимит монеток на арене не учитывается
+func (p *PlayState) spawnCommand(c *console.Console, args []string) error {
+	if err := p.requireRun(); err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return console.ErrUsage
+	}
+	n, err := console.Int(args, 1, "n")
+	if err != nil {
+		return err
+	}
+	if n < 1 || n > maxCommandCount {
+		return fmt.Errorf("%w: n должен быть от 1 до %d", console.ErrUsage, maxCommandCount)
+	}
+
+	switch args[0] {
+	case "enemy":
+		for range n {
+			e := enemy.NewRandomEdgeEnemy(p.rng, p.enemySheet)
+			e.Speed = p.tuning.Enemy.Speed
+			p.enemies = append(p.enemies, e)
+		}
+	case "coin":
+		for range n {
+			p.coins = append(p.coins, game.NewCoin(viewport.ArenaWidth, viewport.ArenaHeight, p.rng, p.coinSheet))
+			p.coinCount++
+		}
+	default:
+		return fmt.Errorf("%w: неизвестный тип %q", console.ErrUsage, args[0])
+	}
+	c.Printf("Создано %s: %d", args[0], n)
+	return nil
+}
+
+// giveCommand начисляет монетки или опыт
+func (p *PlayState) giveCommand(c *console.Console, args []string) error {
+	if err := p.requireRun(); err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return console.ErrUsage
+	}
+	n, err := console.Int(args, 1, "n")
+	if err != nil {
+		return err
+	}
+	if n < 1 {
+		return fmt.Errorf("%w: n должен быть не меньше 1", console.ErrUsage)
+	}
+
+	switch args[0] {
+	case "coins":
+		p.coinsCollected += n
+		p.score += n * p.tuning.Coins.Score
+		c.Printf("Монеток: +%d, счёт: %d", n, p.score)
+	case "xp":
+		p.gainXP(n)
+		c.Printf("Опыт: +%d, уровень %d", n, p.experience.Level)
+	default:
+		return fmt.Errorf("%w: неизвестный тип %q", console.ErrUsage, args[0])
+	}
+	return nil
+}
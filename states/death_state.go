@@ -2,79 +2,201 @@
 package states
 
 import (
-	"fmt"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"image/color"
+	"log"
 	"math"
+	"superpupergame/i18n"
+	"superpupergame/profile"
+	"superpupergame/sound"
+	"superpupergame/text"
 	"superpupergame/ui"
+	"superpupergame/viewport"
 )
 
+// DeathAnimator - игрок, анимация смерти которого показывается на экране смерти
+type DeathAnimator interface {
+	Draw(screen *ebiten.Image)
+	UpdateDeathAnimation()
+}
+
 // DeathState реализует состояние смерти игрока
 type DeathState struct {
 	// stateMachine - ссылка на машину состояний для переключения состояний
 	stateMachine *StateMachine
 	
 	// player - игрок, который умер
-	player interface {
-		Draw(screen *ebiten.Image)
-		UpdateDeathAnimation()
-	}
+	player DeathAnimator
+	
+	// result - итоги забега, переданные игровым состоянием
+	result RunResult
+	
+	// best - лучший счёт в профиле
+	best int
 	
-	// score - итоговый счет
-	score int
+	// profile - профиль игрока, в который записывается имя рекордсмена
+	profile *profile.Profile
+	
+	// nameInput - поле ввода имени для таблицы рекордов
+	nameInput *ui.TextInput
+	
+	// namePrompt - подпись над полем ввода имени
+	namePrompt *ui.Label
 	
 	// deathTimer - таймер с момента смерти
 	deathTimer float64
 	
-	// buttons - кнопки на экране смерти
-	buttons []*ui.Button
+	// cause, bestScore, personalBest, score, level, highScore - строки итогов забега
+	cause, bestScore, personalBest, score, level, highScore *ui.Label
+	
+	// controls - ввод имени и кнопки; появляются после короткой задержки
+	controls *ui.Box
+	
+	// ui - дерево виджетов экрана смерти
+	ui *ui.UI
+	
+	// sound - звуковые эффекты и музыка
+	sound *sound.Manager
+	
+	// world - буфер размером с арену, в котором рисуется умирающий игрок; создаётся при первой отрисовке
+	world *ebiten.Image
 }
 
 // NewDeathState создает новое состояние смерти
-func NewDeathState(stateMachine *StateMachine) *DeathState {
+func NewDeathState(stateMachine *StateMachine, player DeathAnimator, playerProfile *profile.Profile, sound *sound.Manager) *DeathState {
 	// Создаем состояние смерти
 	deathState := &DeathState{
 		stateMachine: stateMachine,
-		buttons:      make([]*ui.Button, 0),
+		player:       player,
+		profile:      playerProfile,
+		sound:        sound,
 	}
 	
-	// Добавляем кнопку "Начать заново"
-	deathState.buttons = append(deathState.buttons, ui.NewButton(
-		540, 400, 200, 50,
-		"Restart",
+	// Поле ввода имени для таблицы рекордов
+	deathState.nameInput = ui.NewTextInput(200, 40, profile.MaxNameLength, deathState.submitName)
+	deathState.namePrompt = ui.NewLabel("death.enter_name", text.Options{})
+	
+	// Добавляем кнопку "Начать заново" - новый забег с тем же зерном
+	restartButton := ui.NewButton(
+		200, 50,
+		"death.restart",
 		color.RGBA{0, 200, 0, 255},
 		func() {
 			// Переключаемся на состояние игры
-			stateMachine.ChangeState("playing")
+			logTransition(stateMachine.ChangeState(StatePlaying, RunConfig{Seed: deathState.result.Seed}))
+		},
+	)
+	
+	// Добавляем кнопку "Таблица рекордов"
+	leaderboardButton := ui.NewButton(
+		200, 50,
+		"death.leaderboard",
+		color.RGBA{60, 120, 200, 255},
+		func() {
+			// Открываем таблицу рекордов поверх экрана смерти, отмечая в ней этот забег
+			logTransition(stateMachine.Push(StateLeaderboard, deathState.result))
 		},
-	))
+	)
+	
+	// Добавляем кнопку "Статистика"
+	statsButton := ui.NewButton(
+		200, 50,
+		"death.stats",
+		color.RGBA{60, 120, 200, 255},
+		func() {
+			// Открываем статистику забега поверх экрана смерти
+			logTransition(stateMachine.Push(StateStats, deathState.result))
+		},
+	)
 	
 	// Добавляем кнопку "В меню"
-	deathState.buttons = append(deathState.buttons, ui.NewButton(
-		540, 500, 200, 50,
-		"Main Menu",
+	menuButton := ui.NewButton(
+		200, 50,
+		"death.menu",
 		color.RGBA{100, 100, 100, 255},
 		func() {
 			// Переключаемся на состояние меню
-			stateMachine.ChangeState("menu")
+			logTransition(stateMachine.ChangeState(StateMenu, nil))
 		},
-	))
+	)
+	
+	buttons := ui.NewVBox(10, restartButton, leaderboardButton, statsButton, menuButton)
+	deathState.controls = ui.NewVBox(10, deathState.namePrompt, deathState.nameInput, buttons)
+	
+	// Итоги забега столбцом по центру экрана; значения подставляются при входе
+	centered := text.Options{Align: text.AlignCenter}
+	deathState.cause = ui.NewLabel("", centered)
+	deathState.bestScore = ui.NewLabel("death.best", centered)
+	deathState.personalBest = ui.NewLabel("death.personal_best", text.Options{Align: text.AlignCenter, Color: color.RGBA{255, 215, 0, 255}})
+	deathState.score = ui.NewLabel("death.score", centered)
+	deathState.level = ui.NewLabel("death.level", centered)
+	deathState.highScore = ui.NewLabel("death.high_score", centered)
+	
+	column := ui.NewVBox(4,
+		ui.NewLabel("death.title", text.Options{Size: text.SizeTitle, Color: color.RGBA{220, 40, 40, 255}, Outline: 2}),
+		deathState.cause,
+		deathState.bestScore,
+		deathState.personalBest,
+		deathState.score,
+		deathState.level,
+		deathState.highScore,
+		deathState.controls,
+	)
+	column.Anchor = ui.AnchorTop
+	column.OffsetY = 200
+	deathState.ui = ui.NewUI(ui.NewFrame(column))
 	
 	return deathState
 }
 
+// submitName записывает введённое имя в таблицу рекордов
+func (d *DeathState) submitName(name string) {
+	if name == "" {
+		name = i18n.T("death.default_name")
+	}
+	d.profile.SetName(d.result.HighScoreRank, name)
+	if err := d.profile.Save(); err != nil {
+		log.Printf("Не удалось сохранить профиль: %v", err)
+	}
+	d.namePrompt.Hidden = true
+	d.nameInput.Hidden = true
+}
+
+// Receive принимает итоги забега от игрового состояния
+func (d *DeathState) Receive(payload any) {
+	d.result, _ = payload.(RunResult)
+}
+
 // Enter вызывается при входе в состояние смерти
 func (d *DeathState) Enter() {
 	// Сбрасываем таймер смерти
 	d.deathTimer = 0
 	
-	// Получаем текущие данные из игрового состояния
-	if playState, ok := d.stateMachine.states["playing"].(*PlayState); ok {
-		d.player = playState.player
-		d.score = playState.score
+	// Включаем музыку экрана смерти
+	d.sound.PlayMusic(sound.MusicDeath)
+	
+	// Лучший счёт уже учитывает этот забег
+	d.best = d.profile.Best()
+	
+	// Подставляем итоги забега; рекорд отмечается вместо лучшего счёта
+	d.cause.Text = d.result.CauseOfDeath
+	d.bestScore.Args = []any{d.best}
+	d.bestScore.Hidden = d.result.PersonalBest
+	d.personalBest.Hidden = !d.result.PersonalBest
+	d.score.Args = []any{d.result.Score}
+	d.level.Args = []any{d.result.Level, d.result.Wave}
+	d.highScore.Args = []any{d.result.HighScoreRank}
+	d.highScore.Hidden = d.result.HighScoreRank == 0
+	
+	// Если забег попал в таблицу рекордов, предлагаем ввести имя; поле сразу принимает ввод
+	enteringName := d.result.HighScoreRank > 0
+	d.namePrompt.Hidden = !enteringName
+	d.nameInput.Hidden = !enteringName
+	if enteringName {
+		d.nameInput.SetText(d.profile.LastName)
+		d.nameInput.Activate()
 	}
+	d.ui.Focus(nil)
 }
 
 // Update обновляет логику состояния смерти
@@ -85,18 +207,11 @@ func (d *DeathState) Update() error {
 	// Обновляем анимацию смерти
 	d.player.UpdateDeathAnimation()
 	
-	// Обработка кнопок на экране смерти
-	if d.deathTimer > 2 && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		// Получаем позицию курсора
-		x, y := ebiten.CursorPosition()
-		
-		// Проверяем, была ли нажата какая-либо кнопка
-		for _, button := range d.buttons {
-			if button.Contains(x, y) {
-				// Вызываем функцию обработки нажатия кнопки
-				button.OnClick()
-			}
-		}
+	// Кнопки и ввод имени становятся доступны после короткой задержки;
+	// пока вводится имя, поле перехватывает ввод и кнопки не реагируют
+	d.controls.Hidden = d.deathTimer <= 2
+	if !d.controls.Hidden {
+		d.ui.Update()
 	}
 	
 	return nil
@@ -104,33 +219,26 @@ func (d *DeathState) Update() error {
 
 // Draw отрисовывает состояние смерти
 func (d *DeathState) Draw(screen *ebiten.Image) {
-	// Заполняем фон
-	ebitenutil.DrawRect(screen, 0, 0, 1280, 960, color.RGBA{50, 50, 50, 255})
-	
-	// Отрисовываем умирающего игрока
-	d.player.Draw(screen)
+	// Заполняем фон вокруг арены, как в игре
+	fillScreen(screen, color.RGBA{30, 30, 30, 255})
 	
-	// Затемняем экран
-	overlay := ebiten.NewImage(1280, 960)
-	overlay.Fill(color.RGBA{0, 0, 0, uint8(math.Min(192, d.deathTimer*80))})
+	// Игрок рисуется в координатах арены, поэтому сначала в буфер арены,
+	// который затем вписывается в экран так же, как в игре
+	if d.world == nil {
+		d.world = ebiten.NewImage(viewport.ArenaWidth, viewport.ArenaHeight)
+	}
+	d.world.Fill(color.RGBA{50, 50, 50, 255})
+	d.player.Draw(d.world)
 	op := &ebiten.DrawImageOptions{}
-	screen.DrawImage(overlay, op)
+	op.GeoM = viewport.Current().ArenaGeoM()
+	screen.DrawImage(d.world, op)
+	
+	// Затемняем экран полупрозрачным прямоугольником, не создавая изображение на каждом кадре
+	fillScreen(screen, color.RGBA{0, 0, 0, uint8(math.Min(192, d.deathTimer*80))})
 	
-	// Отображаем текст и кнопки после задержки
+	// Отображаем итоги и кнопки после задержки
 	if d.deathTimer > 1 {
-		// Отрисовываем текст "Game Over"
-		ebitenutil.DebugPrintAt(screen, "GAME OVER", 580, 300)
-		
-		// Показываем итоговый счёт
-		scoreText := fmt.Sprintf("Final Score: %d", d.score)
-		ebitenutil.DebugPrintAt(screen, scoreText, 580, 350)
-		
-		// Отрисовываем кнопки после короткой задержки
-		if d.deathTimer > 2 {
-			for _, button := range d.buttons {
-				button.Draw(screen)
-			}
-		}
+		d.ui.Draw(screen)
 	}
 }
 
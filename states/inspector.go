@@ -0,0 +1,65 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"slices"
+
+	"superpupergame/debug"
+	"superpupergame/enemy"
+	"superpupergame/game"
+)
+
+// inspecting сообщает, включён ли отладочный инспектор объектов
+func (p *PlayState) inspecting() bool {
+	d := p.player.DebugSystem
+	return d != nil && d.IsEnabled() && d.ShowInspector
+}
+
+// updateInspector обновляет инспектор, пока он включён, и сбрасывает его, когда выключен.
+// Возвращает true, пока в инспекторе вводится значение.
+func (p *PlayState) updateInspector() bool {
+	inspecting := p.inspecting()
+
+	// Пока инспектор включён, щелчок выбирает объект, а не атакует
+	p.player.MouseCaptured = inspecting
+	if !inspecting {
+		p.inspector.Clear()
+		return false
+	}
+	return p.inspector.Update(p.inspectables())
+}
+
+// inspectables возвращает объекты мира в порядке отрисовки: игрок, монетки, сферы опыта, живые враги
+func (p *PlayState) inspectables() []debug.Inspectable {
+	entities := make([]debug.Inspectable, 0, 1+len(p.coins)+len(p.xpOrbs)+len(p.enemies))
+	entities = append(entities, p.player)
+	for _, coin := range p.coins {
+		entities = append(entities, coin)
+	}
+	for _, orb := range p.xpOrbs {
+		entities = append(entities, orb)
+	}
+	for _, e := range p.enemies {
+		if e.Alive {
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+// deleteEntity убирает объект из мира по команде инспектора; игрока удалить нельзя
+func (p *PlayState) deleteEntity(entity debug.Inspectable) bool {
+	switch e := entity.(type) {
+	case *enemy.Enemy:
+		p.enemies = slices.DeleteFunc(p.enemies, func(other *enemy.Enemy) bool { return other == e })
+	case *game.Coin:
+		p.coins = slices.DeleteFunc(p.coins, func(other *game.Coin) bool { return other == e })
+		p.coinCount--
+		p.coinRespawns = append(p.coinRespawns, p.tuning.Coins.RespawnDelay)
+	case *game.XPOrb:
+		p.xpOrbs = slices.DeleteFunc(p.xpOrbs, func(other *game.XPOrb) bool { return other == e })
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,197 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"superpupergame/i18n"
+	"superpupergame/profile"
+	"superpupergame/text"
+	"superpupergame/ui"
+)
+
+// LeaderboardState показывает локальную таблицу рекордов
+type LeaderboardState struct {
+	// stateMachine - ссылка на машину состояний для переключения состояний
+	stateMachine *StateMachine
+
+	// profile - профиль игрока с таблицей рекордов
+	profile *profile.Profile
+
+	// sortBy - текущий порядок сортировки
+	sortBy profile.SortBy
+
+	// modes - режимы для фильтра; пустая строка означает все режимы
+	modes []string
+
+	// modeIndex - индекс выбранного режима в modes
+	modeIndex int
+
+	// entries - отображаемые записи
+	entries []profile.HighScore
+
+	// result - только что завершённый забег, запись которого подсвечивается;
+	// nil, если таблица открыта из главного меню
+	result *RunResult
+
+	// sortButton, modeButton - кнопки переключения сортировки и фильтра
+	sortButton *ui.Button
+	modeButton *ui.Button
+
+	// backButton - кнопка возврата, на которой стоит фокус при входе
+	backButton *ui.Button
+
+	// ui - кнопки экрана
+	ui *ui.UI
+}
+
+// NewLeaderboardState создаёт экран таблицы рекордов
+func NewLeaderboardState(stateMachine *StateMachine, profile *profile.Profile) *LeaderboardState {
+	l := &LeaderboardState{
+		stateMachine: stateMachine,
+		profile:      profile,
+	}
+
+	l.sortButton = ui.NewButton(
+		240, 50,
+		"",
+		color.RGBA{60, 120, 200, 255},
+		l.toggleSort,
+	)
+	l.modeButton = ui.NewButton(
+		240, 50,
+		"",
+		color.RGBA{60, 120, 200, 255},
+		l.nextMode,
+	)
+	backButton := ui.NewButton(
+		240, 50,
+		"common.back",
+		color.RGBA{100, 100, 100, 255},
+		func() {
+			logTransition(stateMachine.Pop())
+		},
+	)
+	row := ui.NewHBox(40, l.sortButton, l.modeButton, backButton)
+	row.Anchor = ui.AnchorBottom
+	row.OffsetY = -90
+	l.ui = ui.NewUI(ui.NewFrame(row))
+	l.backButton = backButton
+
+	return l
+}
+
+// Receive принимает итоги забега, запись которого нужно подсвечивать
+func (l *LeaderboardState) Receive(payload any) {
+	l.result = nil
+	if result, ok := payload.(RunResult); ok {
+		l.result = &result
+	}
+}
+
+// Enter вызывается при входе в состояние таблицы рекордов
+func (l *LeaderboardState) Enter() {
+	l.modes = append([]string{""}, l.profile.Modes()...)
+	if l.modeIndex >= len(l.modes) {
+		l.modeIndex = 0
+	}
+	l.ui.Focus(l.backButton)
+	l.refresh()
+}
+
+// toggleSort переключает сортировку между счётом и волной
+func (l *LeaderboardState) toggleSort() {
+	if l.sortBy == profile.SortByScore {
+		l.sortBy = profile.SortByWave
+	} else {
+		l.sortBy = profile.SortByScore
+	}
+	l.refresh()
+}
+
+// nextMode переключает фильтр на следующий режим игры
+func (l *LeaderboardState) nextMode() {
+	l.modeIndex = (l.modeIndex + 1) % len(l.modes)
+	l.refresh()
+}
+
+// refresh обновляет список записей и подписи кнопок
+func (l *LeaderboardState) refresh() {
+	l.entries = l.profile.Entries(l.sortBy, l.modes[l.modeIndex])
+
+	if l.sortBy == profile.SortByScore {
+		l.sortButton.Text = "leaderboard.sort.score"
+	} else {
+		l.sortButton.Text = "leaderboard.sort.wave"
+	}
+
+	if mode := l.modes[l.modeIndex]; mode == "" {
+		l.modeButton.Text = "leaderboard.mode.all"
+	} else {
+		l.modeButton.Text = i18n.T("leaderboard.mode", mode)
+	}
+}
+
+// Update обрабатывает навигацию по экрану
+func (l *LeaderboardState) Update() error {
+	if ui.BackJustPressed() {
+		return l.stateMachine.Pop()
+	}
+	l.ui.Update()
+	return nil
+}
+
+// Draw отрисовывает таблицу рекордов
+func (l *LeaderboardState) Draw(screen *ebiten.Image) {
+	fillScreen(screen, color.RGBA{50, 50, 50, 255})
+
+	// Таблица шириной 800 пикселей центрируется по экрану
+	center := float64(screen.Bounds().Dx()) / 2
+	left := center - 400
+	text.Draw(screen, i18n.T("leaderboard.title"), center, 170, text.Options{Size: text.SizeTitle, Align: text.AlignCenter, VAlign: text.AlignEnd, Shadow: 3})
+
+	// Заголовок таблицы
+	const rowFormat = "%-4s %-14s %-8s %8s %6s %6s  %-16s %s"
+	header := fmt.Sprintf(rowFormat, "#", i18n.T("leaderboard.column.name"), i18n.T("leaderboard.column.mode"),
+		i18n.T("leaderboard.column.score"), i18n.T("leaderboard.column.wave"), i18n.T("leaderboard.column.level"),
+		i18n.T("leaderboard.column.date"), i18n.T("leaderboard.column.seed"))
+	text.Draw(screen, header, left, 200, text.Options{Font: text.Mono(), Size: text.SizeSmall})
+	ebitenutil.DrawRect(screen, left, 218, 800, 1, color.RGBA{200, 200, 200, 255})
+
+	if len(l.entries) == 0 {
+		text.Draw(screen, i18n.T("leaderboard.empty"), center, 260, text.Options{Align: text.AlignCenter})
+	}
+
+	for i, entry := range l.entries {
+		// Подсвечиваем запись только что завершённого забега
+		if l.result != nil && l.result.Matches(entry) {
+			ebitenutil.DrawRect(screen, left-10, float64(226+i*30), 820, 24, color.RGBA{80, 80, 120, 255})
+		}
+
+		name := entry.Name
+		if name == "" {
+			name = "-"
+		}
+		row := fmt.Sprintf(rowFormat,
+			fmt.Sprintf("%d.", i+1),
+			name,
+			entry.Mode,
+			fmt.Sprint(entry.Score),
+			fmt.Sprint(entry.Wave),
+			fmt.Sprint(entry.Level),
+			entry.Date.Format("2006-01-02 15:04"),
+			fmt.Sprint(entry.Seed),
+		)
+		text.Draw(screen, row, left, float64(230+i*30), text.Options{Font: text.Mono(), Size: text.SizeSmall})
+	}
+
+	l.ui.Draw(screen)
+}
+
+// Exit вызывается при выходе из состояния таблицы рекордов
+func (l *LeaderboardState) Exit() {
+	// Очистка ресурсов при выходе из состояния
+}
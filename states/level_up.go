@@ -0,0 +1,185 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"superpupergame/i18n"
+	"superpupergame/player"
+	"superpupergame/text"
+	"superpupergame/ui"
+)
+
+// LevelUpChoice описывает одно улучшение, которое можно выбрать при повышении уровня
+type LevelUpChoice struct {
+	// Title - ключ строки названия улучшения на кнопке
+	Title string
+
+	// Apply - применяет улучшение к игроку
+	Apply func(p *player.Player)
+
+	// Lasting - улучшение действует до конца забега и показывается в HUD
+	Lasting bool
+}
+
+// levelUpChoices - все доступные улучшения
+var levelUpChoices = []LevelUpChoice{
+	{
+		Title:   "levelup.max_health",
+		Lasting: true,
+		Apply: func(p *player.Player) {
+			p.MaxHealth += 20
+			p.Health += 20
+		},
+	},
+	{
+		Title:   "levelup.move_speed",
+		Lasting: true,
+		Apply: func(p *player.Player) {
+			p.Speed *= 1.15
+			p.DashSpeed *= 1.15
+		},
+	},
+	{
+		Title:   "levelup.dash_charge",
+		Lasting: true,
+		Apply: func(p *player.Player) {
+			p.MaxDashes++
+			p.DashCharges++
+		},
+	},
+	{
+		Title:   "levelup.attack_cooldown",
+		Lasting: true,
+		Apply: func(p *player.Player) {
+			p.AttackCooldown = p.AttackCooldown * 85 / 100
+		},
+	},
+	{
+		Title: "levelup.full_heal",
+		Apply: func(p *player.Player) {
+			p.Health = p.MaxHealth
+		},
+	},
+}
+
+// levelUpKeys - клавиши быстрого выбора улучшений
+var levelUpKeys = []ebiten.Key{ebiten.Key1, ebiten.Key2, ebiten.Key3}
+
+// LevelUpState показывает выбор улучшений поверх приостановленной игры
+type LevelUpState struct {
+	// stateMachine - ссылка на машину состояний для переключения состояний
+	stateMachine *StateMachine
+
+	// play - игровое состояние, в котором игрок получил уровень
+	play *PlayState
+
+	// choices - улучшения, предложенные в текущем выборе
+	choices []LevelUpChoice
+
+	// title, choicesLeft - заголовок с новым уровнем и число оставшихся выборов
+	title, choicesLeft *ui.Label
+
+	// buttons - столбец кнопок выбора улучшений
+	buttons *ui.Box
+
+	// ui - заголовок и кнопки окна выбора
+	ui *ui.UI
+}
+
+// NewLevelUpState создаёт окно выбора улучшений для указанного игрового состояния
+func NewLevelUpState(stateMachine *StateMachine, play *PlayState) *LevelUpState {
+	l := &LevelUpState{
+		stateMachine: stateMachine,
+		play:         play,
+		title:        ui.NewLabel("levelup.title", text.Options{Size: text.SizeLarge, Shadow: 2}),
+		choicesLeft:  ui.NewLabel("levelup.choices_left", text.Options{}),
+		buttons:      ui.NewVBox(30),
+	}
+	l.choicesLeft.Plural = true
+
+	column := ui.NewVBox(10,
+		l.title,
+		l.choicesLeft,
+		l.buttons,
+	)
+	column.Anchor = ui.AnchorTop
+	column.OffsetY = 270
+	l.ui = ui.NewUI(ui.NewFrame(column))
+	return l
+}
+
+// roll выбирает три случайных улучшения и создаёт для них кнопки
+func (l *LevelUpState) roll() {
+	l.choices = l.choices[:0]
+	l.buttons.Items = l.buttons.Items[:0]
+	l.title.Args = []any{l.play.experience.Level}
+	l.choicesLeft.Args = []any{l.play.pendingLevelUps}
+	l.choicesLeft.Hidden = l.play.pendingLevelUps <= 1
+
+	for _, i := range l.play.rng.Perm(len(levelUpChoices))[:len(levelUpKeys)] {
+		l.choices = append(l.choices, levelUpChoices[i])
+	}
+
+	for i, choice := range l.choices {
+		index := i
+		l.buttons.Items = append(l.buttons.Items, ui.NewButton(
+			300, 50,
+			fmt.Sprintf("%d. %s", i+1, i18n.T(choice.Title)),
+			color.RGBA{60, 120, 200, 255},
+			func() {
+				l.choose(index)
+			},
+		))
+	}
+	l.ui.Focus(nil)
+}
+
+// choose применяет выбранное улучшение и переходит к следующему выбору
+// или возвращается в игру, когда все выборы сделаны
+func (l *LevelUpState) choose(index int) {
+	choice := l.choices[index]
+	choice.Apply(l.play.player)
+	if choice.Lasting {
+		l.play.upgrades[choice.Title]++
+	}
+	l.play.pendingLevelUps--
+	if l.play.pendingLevelUps > 0 {
+		l.roll()
+		return
+	}
+	logTransition(l.stateMachine.Pop())
+}
+
+// Enter вызывается при открытии окна выбора
+func (l *LevelUpState) Enter() {
+	l.roll()
+}
+
+// Update обрабатывает выбор улучшения мышью или цифровыми клавишами
+func (l *LevelUpState) Update() error {
+	for i, key := range levelUpKeys {
+		if inpututil.IsKeyJustPressed(key) && i < len(l.choices) {
+			l.choose(i)
+			return nil
+		}
+	}
+
+	l.ui.Update()
+	return nil
+}
+
+// Draw отрисовывает затемнение и кнопки выбора поверх игры
+func (l *LevelUpState) Draw(screen *ebiten.Image) {
+	fillScreen(screen, color.RGBA{0, 0, 0, 160})
+
+	l.ui.Draw(screen)
+}
+
+// Exit вызывается при закрытии окна выбора
+func (l *LevelUpState) Exit() {
+	// Очистка ресурсов при выходе из состояния
+}
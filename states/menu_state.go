@@ -3,10 +3,13 @@ package states
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"image/color"
+	"log"
+	"superpupergame/save"
+	"superpupergame/sound"
+	"superpupergame/text"
 	"superpupergame/ui"
+	"superpupergame/utils"
 	"os"
 )
 
@@ -15,88 +18,149 @@ type MenuState struct {
 	// stateMachine - ссылка на машину состояний для переключения состояний
 	stateMachine *StateMachine
 	
-	// menuItems - элементы меню
-	menuItems []*ui.Button
+	// continueButton - кнопка продолжения сохранённого забега
+	continueButton *ui.Button
+	
+	// message - сообщение об ошибке загрузки сохранения
+	message *ui.Label
+	
+	// ui - дерево виджетов меню
+	ui *ui.UI
+	
+	// sound - звуковые эффекты и музыка
+	sound *sound.Manager
 }
 
 // NewMenuState создает новое состояние меню
-func NewMenuState(stateMachine *StateMachine) *MenuState {
+func NewMenuState(stateMachine *StateMachine, sound *sound.Manager) *MenuState {
 	// Создаем состояние меню
 	menuState := &MenuState{
 		stateMachine: stateMachine,
-		menuItems:    make([]*ui.Button, 0),
+		sound:        sound,
 	}
 	
-	// Добавляем кнопку "Начать новую игру"
-	menuState.menuItems = append(menuState.menuItems, ui.NewButton(
-		540, 300, 200, 50,
-		"Start New Game",
+	// Кнопка "Продолжить" показывается, только если есть сохранение
+	menuState.continueButton = ui.NewButton(
+		200, 50,
+		"menu.continue",
+		color.RGBA{0, 150, 200, 255},
+		menuState.continueRun,
+	)
+	
+	// Кнопка "Начать новую игру"
+	newGameButton := ui.NewButton(
+		200, 50,
+		"menu.new_game",
 		color.RGBA{0, 200, 0, 255},
 		func() {
 			// Переключаемся на состояние игры
-			stateMachine.ChangeState("playing")
+			logTransition(stateMachine.ChangeState(StatePlaying, nil))
+		},
+	)
+	
+	// Кнопка "Таблица рекордов"
+	leaderboardButton := ui.NewButton(
+		200, 50,
+		"menu.leaderboard",
+		color.RGBA{60, 120, 200, 255},
+		func() {
+			// Открываем таблицу рекордов поверх меню
+			logTransition(stateMachine.Push(StateLeaderboard, nil))
 		},
-	))
+	)
 	
-	// Добавляем кнопку "Настройки"
-	menuState.menuItems = append(menuState.menuItems, ui.NewButton(
-		540, 400, 200, 50,
-		"Settings",
+	// Кнопка "Настройки"
+	settingsButton := ui.NewButton(
+		200, 50,
+		"menu.settings",
 		color.RGBA{100, 100, 100, 255},
 		func() {
-			// Заглушка для настроек
+			// Открываем экран настроек поверх меню
+			logTransition(stateMachine.Push(StateSettings, nil))
 		},
-	))
+	)
 	
-	// Добавляем кнопку "Выход"
-	menuState.menuItems = append(menuState.menuItems, ui.NewButton(
-		540, 500, 200, 50,
-		"Exit",
+	// Кнопка "Выход"
+	exitButton := ui.NewButton(
+		200, 50,
+		"menu.exit",
 		color.RGBA{200, 0, 0, 255},
 		func() {
 			os.Exit(0)
 		},
-	))
+	)
+	
+	// Сообщение об ошибке загрузки сохранения под кнопками
+	menuState.message = ui.NewLabel("", text.Options{Align: text.AlignCenter, MaxWidth: 800, Color: color.RGBA{255, 120, 120, 255}})
+	menuState.message.Hidden = true
+	
+	// Заголовок и кнопки столбцом по центру экрана
+	title := ui.NewLabel("menu.title", text.Options{Size: text.SizeTitle, Shadow: 3})
+	column := ui.NewVBox(20,
+		title,
+		menuState.continueButton,
+		newGameButton,
+		leaderboardButton,
+		settingsButton,
+		exitButton,
+		menuState.message,
+	)
+	column.Anchor = ui.AnchorTop
+	column.OffsetY = 150
+	menuState.ui = ui.NewUI(ui.NewFrame(column))
 	
 	return menuState
 }
 
 // Enter вызывается при входе в состояние меню
 func (m *MenuState) Enter() {
-	// Инициализация состояния меню
+	// Включаем музыку меню
+	m.sound.PlayMusic(sound.MusicMenu)
+	
+	// Кнопка "Продолжить" видна, только если есть сохранённый забег
+	m.continueButton.Hidden = !save.Exists()
+	m.ui.Focus(nil)
 }
 
-// Update обновляет логику меню
-func (m *MenuState) Update() error {
-	// Проверяем нажатие кнопки мыши
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		// Получаем позицию курсора
-		x, y := ebiten.CursorPosition()
-		
-		// Проверяем, была ли нажата какая-либо кнопка
-		for _, button := range m.menuItems {
-			if button.Contains(x, y) {
-				// Вызываем функцию обработки нажатия кнопки
-				button.OnClick()
-			}
+// continueRun загружает сохранённый забег и переходит в игру
+func (m *MenuState) continueRun() {
+	snapshot, err := save.Load()
+	if err != nil {
+		// Повреждённое или устаревшее сохранение не должно мешать начать новую игру
+		log.Printf("Не удалось загрузить сохранение: %v", err)
+		m.message.Text = "menu.load_failed"
+		m.message.Hidden = false
+		m.continueButton.Hidden = true
+		if err := save.Remove(); err != nil {
+			log.Printf("Не удалось удалить сохранение: %v", err)
 		}
+		return
 	}
 	
+	// Сохранённый забег передаётся игровому состоянию вместе с переходом
+	m.message.Hidden = true
+	logTransition(m.stateMachine.ChangeStateWith(StatePlaying, snapshot, Transition{
+		Kind:     TransitionWipe,
+		Duration: 0.5,
+		Easing:   utils.EaseInOutCubic,
+	}))
+}
+
+// Update обновляет логику меню
+func (m *MenuState) Update() error {
+	// Обрабатываем навигацию и нажатие кнопок
+	m.ui.Update()
+	
 	return nil
 }
 
 // Draw отрисовывает меню
 func (m *MenuState) Draw(screen *ebiten.Image) {
 	// Заполняем фон
-	ebitenutil.DrawRect(screen, 0, 0, 1280, 960, color.RGBA{50, 50, 50, 255})
+	fillScreen(screen, color.RGBA{50, 50, 50, 255})
 	
-	// Отрисовываем заголовок игры
-	ebitenutil.DebugPrintAt(screen, "SuperPuperGame", 580, 200)
-	
-	// Отрисовываем кнопки меню
-	for _, button := range m.menuItems {
-		button.Draw(screen)
-	}
+	// Отрисовываем заголовок, кнопки и сообщение
+	m.ui.Draw(screen)
 }
 
 // Exit вызывается при выходе из состояния меню
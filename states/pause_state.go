@@ -0,0 +1,118 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/text"
+	"superpupergame/ui"
+	"superpupergame/utils"
+)
+
+// PauseState показывает меню паузы поверх замороженной игры
+type PauseState struct {
+	// stateMachine - ссылка на машину состояний для переключения состояний
+	stateMachine *StateMachine
+
+	// play - игровое состояние под паузой
+	play *PlayState
+
+	// ui - заголовок и кнопки меню паузы
+	ui *ui.UI
+}
+
+// NewPauseState создаёт меню паузы для указанного игрового состояния
+func NewPauseState(stateMachine *StateMachine, play *PlayState) *PauseState {
+	p := &PauseState{
+		stateMachine: stateMachine,
+		play:         play,
+	}
+
+	resumeButton := ui.NewButton(
+		200, 50,
+		"pause.resume",
+		color.RGBA{0, 200, 0, 255},
+		p.resume,
+	)
+	restartButton := ui.NewButton(
+		200, 50,
+		"pause.restart",
+		color.RGBA{60, 120, 200, 255},
+		func() {
+			// Повторный вход в игровое состояние начинает новый забег
+			logTransition(stateMachine.ChangeStateWith(StatePlaying, nil, Transition{
+				Kind:     TransitionPixelate,
+				Duration: 0.6,
+				Easing:   utils.EaseInOutQuad,
+			}))
+		},
+	)
+	settingsButton := ui.NewButton(
+		200, 50,
+		"pause.settings",
+		color.RGBA{100, 100, 100, 255},
+		func() {
+			logTransition(stateMachine.Push(StateSettings, nil))
+		},
+	)
+	quitButton := ui.NewButton(
+		200, 50,
+		"pause.quit",
+		color.RGBA{200, 0, 0, 255},
+		p.quit,
+	)
+	column := ui.NewVBox(20,
+		ui.NewLabel("pause.title", text.Options{Size: text.SizeTitle, Shadow: 3}),
+		resumeButton,
+		restartButton,
+		settingsButton,
+		quitButton,
+	)
+	column.Anchor = ui.AnchorTop
+	column.OffsetY = 270
+	p.ui = ui.NewUI(ui.NewFrame(column))
+
+	return p
+}
+
+// resume закрывает паузу и продолжает игру
+func (p *PauseState) resume() {
+	logTransition(p.stateMachine.Pop())
+}
+
+// quit сохраняет забег и выходит в главное меню, откуда его можно продолжить
+func (p *PauseState) quit() {
+	if err := p.play.SaveRun(); err != nil {
+		log.Printf("Не удалось сохранить забег: %v", err)
+	}
+	logTransition(p.stateMachine.ChangeState(StateMenu, nil))
+}
+
+// Enter вызывается при постановке игры на паузу
+func (p *PauseState) Enter() {
+	p.ui.Focus(nil)
+}
+
+// Update обрабатывает меню паузы
+func (p *PauseState) Update() error {
+	// Escape снимает игру с паузы
+	if ui.BackJustPressed() {
+		p.resume()
+		return nil
+	}
+	p.ui.Update()
+	return nil
+}
+
+// Draw отрисовывает затемнение и меню поверх игры
+func (p *PauseState) Draw(screen *ebiten.Image) {
+	fillScreen(screen, color.RGBA{0, 0, 0, 160})
+	p.ui.Draw(screen)
+}
+
+// Exit вызывается при снятии паузы
+func (p *PauseState) Exit() {
+	// Очистка ресурсов при выходе из состояния
+}
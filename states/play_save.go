@@ -0,0 +1,214 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"fmt"
+	"log"
+	"maps"
+
+	"superpupergame/enemy"
+	"superpupergame/game"
+	"superpupergame/profile"
+	"superpupergame/save"
+)
+
+// Snapshot возвращает полный снимок текущего забега для сохранения
+func (p *PlayState) Snapshot() (*save.Snapshot, error) {
+	rngState, err := p.rngSource.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось сохранить состояние генератора: %w", err)
+	}
+
+	snapshot := &save.Snapshot{
+		Seed:  p.seed,
+		RNG:   rngState,
+		Score: p.score,
+		Wave:  p.enemyCount,
+		Level: p.experience.Level,
+		XP:    p.experience.XP,
+		Player: save.PlayerData{
+			X:                  p.player.X,
+			Y:                  p.player.Y,
+			Health:             p.player.Health,
+			MaxHealth:          p.player.MaxHealth,
+			Speed:              p.player.Speed,
+			DashSpeed:          p.player.DashSpeed,
+			DashCharges:        p.player.DashCharges,
+			MaxDashes:          p.player.MaxDashes,
+			Dashing:            p.player.Dashing,
+			DashTimer:          p.player.DashTimer,
+			DashRecharge:       append([]float64(nil), p.player.DashRecharge...),
+			Attacking:          p.player.Attacking,
+			AttackTimer:        p.player.AttackTimer,
+			AttackAngle:        p.player.AttackAngle,
+			AttackCooldown:     p.player.AttackCooldown,
+			AttackCooldownLeft: p.player.AttackRecharge,
+			Facing:             p.player.Facing,
+		},
+		CoinRespawns:   append([]float64(nil), p.coinRespawns...),
+		WaveBreak:      p.waveBreak,
+		Kills:          p.kills,
+		CoinsCollected: p.coinsCollected,
+		DashesUsed:     p.player.DashesUsed,
+		Elapsed:        p.elapsed,
+		Combo:          p.combo,
+		ComboTimer:     p.comboTimer,
+
+		PendingLevelUps: p.pendingLevelUps,
+		Upgrades:        maps.Clone(p.upgrades),
+	}
+
+	for _, e := range p.enemies {
+		snapshot.Enemies = append(snapshot.Enemies, save.EnemyData{
+			X:     e.X,
+			Y:     e.Y,
+			Speed: e.Speed,
+			Alive: e.Alive,
+		})
+	}
+
+	for _, coin := range p.coins {
+		snapshot.Coins = append(snapshot.Coins, save.CoinData{
+			X:     coin.GetX(),
+			Y:     coin.GetY(),
+			Frame: coin.Frame(),
+		})
+	}
+
+	for _, orb := range p.xpOrbs {
+		snapshot.XPOrbs = append(snapshot.XPOrbs, save.OrbData{
+			X:     orb.GetX(),
+			Y:     orb.GetY(),
+			Value: orb.Value,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// SaveRun записывает текущий забег в файл сохранения
+func (p *PlayState) SaveRun() error {
+	// Умерший игрок не может продолжить забег
+	if p.player.Dying {
+		return nil
+	}
+
+	snapshot, err := p.Snapshot()
+	if err != nil {
+		return err
+	}
+	if err := save.Save(snapshot); err != nil {
+		return err
+	}
+
+	// Итоги сохранённого забега запишутся в профиль, когда он закончится после продолжения
+	p.runActive = false
+	return nil
+}
+
+// recordSavedRun записывает в профиль статистику сохранённого забега, который заменяется новым
+func (p *PlayState) recordSavedRun() {
+	if !save.Exists() {
+		return
+	}
+	s, err := save.Load()
+	if err != nil {
+		log.Printf("Не удалось загрузить сохранение для статистики: %v", err)
+		return
+	}
+	p.profile.RecordRun(profile.Run{
+		Score:      s.Score,
+		Wave:       s.Wave,
+		Level:      s.Level,
+		Seed:       s.Seed,
+		Kills:      s.Kills,
+		Coins:      s.CoinsCollected,
+		DashesUsed: s.DashesUsed,
+		PlayTime:   s.Elapsed,
+		Abandoned:  true,
+	})
+	p.saveProfile()
+}
+
+// InProgress сообщает, идёт ли сейчас забег, в том числе стоящий на паузе
+func (p *PlayState) InProgress() bool {
+	return p.stateMachine.contains(p) && !p.player.Dying
+}
+
+// restore восстанавливает забег из снимка
+func (p *PlayState) restore(s *save.Snapshot) {
+	// Без состояния генератора забег всё равно можно продолжить с исходного зерна
+	p.reseed(s.Seed)
+	if err := p.rngSource.UnmarshalBinary(s.RNG); err != nil {
+		p.rngSource.Seed(s.Seed, s.Seed)
+	}
+
+	// Восстанавливаем игрока
+	p.player.ResetStats()
+	p.player.X = s.Player.X
+	p.player.Y = s.Player.Y
+	p.player.Health = s.Player.Health
+	p.player.MaxHealth = s.Player.MaxHealth
+	p.player.Speed = s.Player.Speed
+	p.player.DashSpeed = s.Player.DashSpeed
+	p.player.DashCharges = s.Player.DashCharges
+	p.player.MaxDashes = s.Player.MaxDashes
+	p.player.Dashing = s.Player.Dashing
+	p.player.DashTimer = s.Player.DashTimer
+	p.player.DashRecharge = append([]float64(nil), s.Player.DashRecharge...)
+	p.player.Attacking = s.Player.Attacking
+	p.player.AttackTimer = s.Player.AttackTimer
+	p.player.AttackAngle = s.Player.AttackAngle
+	p.player.AttackCooldown = s.Player.AttackCooldown
+	p.player.AttackRecharge = max(0, s.Player.AttackCooldownLeft)
+	p.player.Facing = s.Player.Facing
+	p.player.Dying = false
+	p.player.DeathTimer = 0
+
+	// Восстанавливаем врагов
+	p.enemies = nil
+	for _, e := range s.Enemies {
+		restored := enemy.NewEnemy(e.X, e.Y, p.enemySheet)
+		restored.Speed = e.Speed
+		restored.Alive = e.Alive
+		p.enemies = append(p.enemies, restored)
+	}
+	p.waveBreak = s.WaveBreak
+
+	// Восстанавливаем монетки
+	p.coins = make([]*game.Coin, 0, len(s.Coins))
+	for _, c := range s.Coins {
+		coin := game.NewCoinAt(c.X, c.Y, p.coinSheet)
+		coin.SetFrame(c.Frame)
+		p.coins = append(p.coins, coin)
+	}
+	p.coinCount = len(p.coins)
+	p.coinRespawns = append([]float64(nil), s.CoinRespawns...)
+
+	// Восстанавливаем сферы опыта
+	p.xpOrbs = nil
+	for _, o := range s.XPOrbs {
+		p.xpOrbs = append(p.xpOrbs, game.NewXPOrb(o.X, o.Y, o.Value))
+	}
+
+	// Восстанавливаем счёт, волну и уровень
+	p.score = s.Score
+	p.enemyCount = s.Wave
+	p.experience.Level = s.Level
+	p.experience.XP = s.XP
+
+	// Восстанавливаем статистику забега
+	p.kills = s.Kills
+	p.coinsCollected = s.CoinsCollected
+	p.player.DashesUsed = s.DashesUsed
+	p.elapsed = s.Elapsed
+	p.combo = s.Combo
+	p.comboTimer = s.ComboTimer
+
+	// Выборы улучшений, оставшиеся несделанными; окно выбора откроется при входе
+	p.pendingLevelUps = s.PendingLevelUps
+	p.upgrades = maps.Clone(s.Upgrades)
+	if p.upgrades == nil {
+		p.upgrades = make(map[string]int)
+	}
+}
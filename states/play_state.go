@@ -3,16 +3,27 @@ package states
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"image/color"
-	"math/rand"
+	"log"
+	"math/rand/v2"
+	"superpupergame/animation"
+	"superpupergame/assets"
+	"superpupergame/camera"
+	"superpupergame/debug"
 	"superpupergame/enemy"
 	"superpupergame/game" // Импортируем пакет с монеткой
+	"superpupergame/i18n"
+	"superpupergame/particles"
 	"superpupergame/player"
+	"superpupergame/profile"
+	"superpupergame/profiler"
+	"superpupergame/save"
+	"superpupergame/sound"
 	"superpupergame/ui"
+	"superpupergame/utils"
+	"superpupergame/viewport"
 	"time"
 	"math"
-	"fmt"
 )
 
 // PlayState реализует игровое состояние
@@ -32,98 +43,505 @@ type PlayState struct {
 	// coinCount - количество активных монеток
 	coinCount int
 	
-	// maxCoins - максимальное количество монеток на экране
-	maxCoins int
-	
-	// enemyCount - количество врагов в текущей волне
+	// enemyCount - номер текущей волны
 	enemyCount int
 	
+	// tuning - игровые числа: враги, волны, монетки и кривая уровней
+	tuning *game.Tuning
+	
 	// score - текущий счет
 	score int
 	
 	// hud - элементы интерфейса
 	hud *ui.HUD
+	
+	// xpOrbs - сферы опыта, выпавшие из врагов
+	xpOrbs []*game.XPOrb
+	
+	// experience - уровень и опыт игрока
+	experience *game.Experience
+	
+	// pendingLevelUps - сколько выборов улучшений ещё не сделано
+	pendingLevelUps int
+	
+	// seed - зерно генератора случайных чисел текущего забега
+	seed uint64
+	
+	// rngSource - источник случайных чисел, состояние которого сохраняется вместе с забегом
+	rngSource *rand.PCG
+	
+	// rng - генератор случайных чисел забега
+	rng *rand.Rand
+	
+	// coinRespawns - оставшееся время до появления отложенных монеток
+	coinRespawns []float64
+	
+	// pendingRestore - сохранённый забег, который нужно восстановить при входе
+	pendingRestore *save.Snapshot
+	
+	// kills - количество врагов, убитых за забег
+	kills int
+	
+	// coinsCollected - количество монеток, собранных за забег
+	coinsCollected int
+	
+	// elapsed - время забега (в секундах)
+	elapsed float64
+	
+	// profile - профиль игрока с рекордами и статистикой
+	profile *profile.Profile
+	
+	// runConfig - параметры нового забега, переданные при переходе (nil - случайное зерно)
+	runConfig *RunConfig
+	
+	// sound - звуковые эффекты и музыка
+	sound *sound.Manager
+	
+	// particles - частицы для попаданий, смертей, рывков и подбора предметов
+	particles *particles.System
+	
+	// dashTrail - след частиц за игроком во время рывка
+	dashTrail *particles.Emitter
+	
+	// camera - тряска экрана, хитстоп и толчки камеры
+	camera *camera.Camera
+	
+	// coinSheet - лист анимации, общий для всех монеток
+	coinSheet *animation.Sheet
+	
+	// enemySheet - лист анимации, общий для всех врагов
+	enemySheet *animation.Sheet
+	
+	// upgrades - сколько раз взято каждое улучшение, действующее до конца забега
+	upgrades map[string]int
+	
+	// combo - убийства подряд, между которыми прошло не больше comboWindow
+	combo int
+	
+	// comboTimer - сколько секунд ещё продолжается серия убийств
+	comboTimer float64
+	
+	// god - игрок не получает урона (команда консоли god)
+	god bool
+	
+	// timeScale - скорость симуляции: сколько тиков мира приходится на один кадр
+	timeScale float64
+	
+	// timeDebt - накопленная дробная часть тиков при timeScale, отличной от 1
+	timeDebt float64
+	
+	// simPaused - симуляция остановлена (F7 или команда pause); отрисовка продолжается
+	simPaused bool
+	
+	// pendingSteps - сколько тиков сделать в остановленной симуляции (F8 или команда step)
+	pendingSteps int
+	
+	// waveBreak - сколько секунд прошло с уничтожения последнего врага волны
+	waveBreak float64
+	
+	// runActive - забег идёт и его итоги ещё не записаны в профиль и не сохранены для продолжения
+	runActive bool
+	
+	// inspector - отладочный инспектор объектов (F6 в режиме отладки)
+	inspector *debug.Inspector
 }
 
+// comboWindow - сколько секунд после убийства серия ждёт следующего
+const comboWindow = 2.0
+
+// wavePause - пауза между уничтожением волны и появлением следующей (в секундах)
+const wavePause = 0.5
+
 // NewPlayState создает новое игровое состояние
-func NewPlayState(stateMachine *StateMachine, player *player.Player) *PlayState {
+func NewPlayState(stateMachine *StateMachine, player *player.Player, profile *profile.Profile, sound *sound.Manager, presets map[string]*particles.Preset, camera *camera.Camera, hud *ui.HUD, assetManager *assets.Manager, tuning *game.Tuning) *PlayState {
+	// Создаем систему частиц с непрерывным следом для рывка
+	particleSystem := particles.NewSystem(presets, particles.DefaultCapacity)
+	
+	// Шаги игрока поднимают пыль; событие "step" задано в клипах ходьбы
+	player.Animator.OnEvent = func(name string) {
+		if name == "step" {
+			particleSystem.Burst("step", player.X+16, player.Y+30)
+		}
+	}
+	
+	// Загружаем листы анимаций; при ошибке вместо спрайтов рисуется заглушка
+	coinSheet, err := assetManager.Sheet("coin.json")
+	if err != nil {
+		log.Printf("Ошибка загрузки анимации монетки: %v", err)
+	}
+	enemySheet, err := assetManager.Sheet("enemy.json")
+	if err != nil {
+		log.Printf("Ошибка загрузки анимации врага: %v", err)
+	}
+	
+	// Генератор готов сразу, чтобы им можно было пользоваться и до первого забега
+	rngSource := rand.NewPCG(0, 0)
+	
 	// Создаем игровое состояние
-	return &PlayState{
+	p := &PlayState{
+		coinSheet:    coinSheet,
+		enemySheet:   enemySheet,
+		particles:    particleSystem,
+		dashTrail:    particleSystem.NewEmitter("dash_trail"),
 		stateMachine: stateMachine,
 		player:       player,
+		profile:      profile,
+		sound:        sound,
+		camera:       camera,
+		tuning:       tuning,
 		enemyCount:   1,
 		score:        0,
-		hud:          ui.NewHUD(),
+		hud:          hud,
 		coins:        make([]*game.Coin, 0),
 		coinCount:    0,
-		maxCoins:     5, // Максимальное количество монеток на экране
+		experience:   game.NewExperience(tuning.Level),
+		rngSource:    rngSource,
+		rng:          rand.New(rngSource),
+		upgrades:     make(map[string]int),
+		timeScale:    1,
+		inspector:    debug.NewInspector(),
 	}
+	p.inspector.OnDelete = p.deleteEntity
+	p.registerCommands()
+	return p
+}
+
+// reseed задаёт новое зерно генератора случайных чисел забега
+func (p *PlayState) reseed(seed uint64) {
+	p.seed = seed
+	p.rngSource.Seed(seed, seed)
+	p.rng = rand.New(p.rngSource)
 }
 
 // SpawnCoin создает новую монетку
 func (p *PlayState) SpawnCoin() {
 	// Создаем новую монетку если не превышен лимит
-	if p.coinCount < p.maxCoins {
-		p.coins = append(p.coins, game.NewCoin(1280, 960))
+	if p.coinCount < p.tuning.Coins.Max {
+		p.coins = append(p.coins, game.NewCoin(viewport.ArenaWidth, viewport.ArenaHeight, p.rng, p.coinSheet))
 		p.coinCount++
 	}
 }
 
+// spawnWave заменяет врагов волной с номером enemyCount
+func (p *PlayState) spawnWave() {
+	p.enemies = nil
+	p.waveBreak = 0
+	for i := 0; i < p.tuning.WaveEnemies(p.enemyCount); i++ {
+		e := enemy.NewRandomEdgeEnemy(p.rng, p.enemySheet)
+		e.Speed = p.tuning.Enemy.Speed
+		p.enemies = append(p.enemies, e)
+	}
+}
+
+// ApplyTuning заменяет игровые числа, не сбрасывая текущий забег:
+// живые враги сразу получают новую скорость, а волны и награды меняются со следующего события
+func (p *PlayState) ApplyTuning(tuning *game.Tuning) {
+	p.tuning = tuning
+	p.experience.Curve = tuning.Level
+	for _, e := range p.enemies {
+		if e.Alive {
+			e.Speed = tuning.Enemy.Speed
+		}
+	}
+}
+
+// SetParticlePresets заменяет пресеты частиц, не останавливая живые частицы
+func (p *PlayState) SetParticlePresets(presets map[string]*particles.Preset) {
+	p.particles.SetPresets(presets)
+}
+
+// Receive принимает данные перехода: сохранённый забег, который нужно продолжить,
+// или параметры нового забега
+func (p *PlayState) Receive(payload any) {
+	p.pendingRestore = nil
+	p.runConfig = nil
+	switch payload := payload.(type) {
+	case *save.Snapshot:
+		p.pendingRestore = payload
+	case RunConfig:
+		p.runConfig = &payload
+	}
+}
+
 // Enter вызывается при входе в игровое состояние
 func (p *PlayState) Enter() {
+	// Включаем игровую музыку
+	p.sound.PlayMusic(sound.MusicPlay)
+	
+	// Частицы и тряска прошлого забега не переносятся в новый
+	p.particles.Clear()
+	p.camera.Reset()
+	
+	// Недоделанные тики, шаги, нажатия и выбор в инспекторе прошлого забега тоже
+	p.timeDebt = 0
+	p.pendingSteps = 0
+	p.player.ResetInput()
+	p.inspector.Clear()
+	
+	// Если выбрано продолжение сохранённого забега, восстанавливаем его
+	if p.pendingRestore != nil {
+		p.restore(p.pendingRestore)
+		p.pendingRestore = nil
+		p.runActive = true
+		
+		// Если забег был сохранён во время выбора улучшений, открываем выбор снова
+		if p.pendingLevelUps > 0 {
+			logTransition(p.stateMachine.Push(StateLevelUp, nil))
+		}
+		return
+	}
+	
+	// Начинаем новый забег с заданным или случайным зерном; старое сохранение больше не нужно
+	if p.runConfig != nil {
+		p.reseed(p.runConfig.Seed)
+	} else {
+		p.reseed(uint64(time.Now().UnixNano()))
+	}
+	// Сохранённый забег, который заменяется новым, закончен: его статистика уходит в профиль
+	p.recordSavedRun()
+	if err := save.Remove(); err != nil {
+		log.Printf("Не удалось удалить сохранение: %v", err)
+	}
+	p.runActive = true
 	
 	// Сбрасываем параметры существующего игрока
-    p.player.X = 640
-    p.player.Y = 480
-    p.player.Health = 100
+    p.player.ResetStats()
+    p.player.X = viewport.ArenaWidth / 2
+    p.player.Y = viewport.ArenaHeight / 2
+    p.player.Health = p.player.MaxHealth
     p.player.Dying = false
     p.player.Attacking = false
     p.player.Dashing = false
     p.player.DashCharges = p.player.MaxDashes
+    p.player.DashTimer = 0
+    p.player.DashRecharge = nil
+    p.player.AttackTimer = 0
+    p.player.DashesUsed = 0
+    p.player.AttackRecharge = 0
     p.player.DeathTimer = 0
 	
-	// Создаем первого врага
-	p.enemies = []*enemy.Enemy{enemy.NewRandomEdgeEnemy()}
+	// Создаем первую волну
+	p.enemyCount = 1
+	p.spawnWave()
 	
 	// Очищаем список монеток
 	p.coins = make([]*game.Coin, 0)
 	p.coinCount = 0
+	p.coinRespawns = nil
 	
 	// Создаем начальные монетки
-	for i := 0; i < 3; i++ {
+	for i := 0; i < p.tuning.Coins.Start; i++ {
 		p.SpawnCoin()
 	}
 	
-	// Сбрасываем счет
+	// Сбрасываем счет и статистику забега
 	p.score = 0
+	p.kills = 0
+	p.coinsCollected = 0
+	p.elapsed = 0
 	
-	// Сбрасываем количество врагов
-	p.enemyCount = 1
+	// Сбрасываем уровень, опыт и сферы опыта
+	p.experience.Reset()
+	p.xpOrbs = nil
+	p.pendingLevelUps = 0
+	
+	// Улучшения и серия убийств не переносятся в новый забег
+	clear(p.upgrades)
+	p.combo = 0
+	p.comboTimer = 0
+}
+
+// Pause вызывается, когда поверх игры открывается пауза или выбор улучшений
+func (p *PlayState) Pause() {}
+
+// Resume открывает выбор улучшений, если уровни были получены, пока игра была закрыта другим состоянием
+// (например, командой консоли give xp во время паузы)
+func (p *PlayState) Resume() {
+	if p.pendingLevelUps > 0 && p.InProgress() {
+		logTransition(p.stateMachine.Push(StateLevelUp, nil))
+	}
+}
+
+// finishRun подводит итоги завершённого забега и записывает их в профиль игрока
+func (p *PlayState) finishRun(cause string) RunResult {
+	result := p.runResult(cause)
+	result.HighScoreRank, result.PersonalBest = p.profile.RecordRun(result.Run())
+	p.runActive = false
+	p.saveProfile()
+	return result
+}
+
+// abandonRun записывает в профиль статистику забега, брошенного без сохранения (перезапуск, выход в меню);
+// сохранение, из которого забег мог быть продолжен, больше не нужно
+func (p *PlayState) abandonRun() {
+	run := p.runResult("").Run()
+	run.Abandoned = true
+	p.profile.RecordRun(run)
+	p.runActive = false
+	p.saveProfile()
+	if err := save.Remove(); err != nil {
+		log.Printf("Не удалось удалить сохранение: %v", err)
+	}
+}
+
+// saveProfile записывает профиль игрока на диск
+func (p *PlayState) saveProfile() {
+	if err := p.profile.Save(); err != nil {
+		log.Printf("Не удалось сохранить профиль: %v", err)
+	}
+}
+
+// runResult возвращает итоги текущего забега; cause - ключ строки причины смерти
+func (p *PlayState) runResult(cause string) RunResult {
+	return RunResult{
+		Score:        p.score,
+		Wave:         p.enemyCount,
+		Level:        p.experience.Level,
+		Kills:        p.kills,
+		Coins:        p.coinsCollected,
+		DashesUsed:   p.player.DashesUsed,
+		Time:         p.elapsed,
+		Seed:         p.seed,
+		CauseOfDeath: cause,
+	}
+}
+
+// gainXP добавляет опыт и открывает окно выбора улучшений при повышении уровня
+func (p *PlayState) gainXP(amount int) {
+	gained := p.experience.Add(amount)
+	if gained == 0 {
+		return
+	}
+	
+	// Если окно выбора уже открыто в этом кадре, оно просто получит больше выборов
+	p.pendingLevelUps += gained
+	if p.stateMachine.current() == p {
+		logTransition(p.stateMachine.Push(StateLevelUp, nil))
+	}
 }
 
 // Update обновляет игровую логику
 func (p *PlayState) Update() error {
-	// Обновляем игрока
-	p.player.Update()
+	// Пока в инспекторе вводится значение, клавиатура принадлежит ему, а мир стоит
+	if p.updateInspector() {
+		return nil
+	}
+	
+	// Пауза по Escape или при потере фокуса окном
+	if ui.BackJustPressed() || !ebiten.IsFocused() {
+		return p.stateMachine.Push(StatePaused, nil)
+	}
+	
+	// HUD обновляется и во время хитстопа, чтобы след полосы здоровья не замирал
+	timer := profiler.Begin("hud_update")
+	p.hud.Update(p.hudModel())
+	timer.End()
+	
+	// Отладочные клавиши остановки, шага и скорости симуляции
+	p.updateTimeControls()
+	
+	// Нажатия запоминаются каждый кадр: при замедлении или пошаговом режиме
+	// в кадре может не быть ни одного тика, и иначе короткий щелчок потерялся бы
+	p.player.SampleInput()
+	
+	// За кадр делается столько тиков мира, сколько накопилось при текущей скорости симуляции;
+	// остановленная симуляция делает только запрошенные шаги
+	if p.simPaused {
+		p.timeDebt = float64(p.pendingSteps)
+		p.pendingSteps = 0
+	} else {
+		p.timeDebt += p.timeScale
+	}
+	for p.timeDebt >= 1 {
+		p.timeDebt--
+		if err := p.step(); err != nil {
+			return err
+		}
+		
+		// После смерти или открытия выбора улучшений оставшиеся тики не нужны
+		if p.stateMachine.current() != p || p.stateMachine.Transitioning() {
+			p.timeDebt = 0
+			break
+		}
+	}
+	return nil
+}
+
+// step продвигает мир на один тик
+func (p *PlayState) step() error {
+	// Во время хитстопа мир стоит, а камера продолжает трястись
+	p.camera.Update()
+	if p.camera.Frozen() {
+		return nil
+	}
+	
+	// Учитываем время забега
+	p.elapsed += 1.0 / 60.0
+	
+	// Серия убийств обрывается, если следующего убийства долго нет
+	if p.comboTimer > 0 {
+		p.comboTimer -= 1.0 / 60.0
+		if p.comboTimer <= 0 {
+			p.combo = 0
+		}
+	}
+	
+	// Обновляем игрока, запоминая состояние до обновления для звуков взмаха и рывка
+	wasAttacking, wasDashing := p.player.Attacking, p.player.Dashing
+	timer := profiler.Begin("player")
+	if !p.inspector.Frozen(p.player) {
+		p.player.Update()
+	}
+	timer.End()
+	if p.player.Attacking && !wasAttacking {
+		p.sound.Play(sound.Swing)
+	}
+	if p.player.Dashing && !wasDashing {
+		p.sound.Play(sound.Dash)
+		p.particles.Burst("dash", p.player.X+10, p.player.Y+10)
+		p.camera.Kick(p.player.DirX*10, p.player.DirY*10)
+	}
+	
+	// След рывка следует за игроком, пока рывок длится
+	p.dashTrail.Active = p.player.Dashing
+	p.dashTrail.X, p.dashTrail.Y = p.player.X+10, p.player.Y+10
+	timer = profiler.Begin("particles")
+	p.particles.Update()
+	timer.End()
 	
 	// Обновляем все монетки (анимация)
+	timer = profiler.Begin("coins_anim")
 	for _, coin := range p.coins {
-        coin.Update()
+        if !p.inspector.Frozen(coin) {
+            coin.Update()
+        }
     }
+	timer.End()
+	
+	// Количество объектов для профилировщика
+	profiler.Count("enemies", len(p.enemies))
+	profiler.Count("coins", len(p.coins))
+	profiler.Count("xp_orbs", len(p.xpOrbs))
+	profiler.Count("particles", p.particles.Count())
 
 	// Добавляем отладочную информацию о количестве объектов
 	if p.player.DebugSystem != nil && p.player.DebugSystem.IsEnabled() {
         p.player.DebugSystem.ClearMessages()
-        p.player.DebugSystem.AddMessage(fmt.Sprintf("Враги: %d", len(p.enemies)))
-        p.player.DebugSystem.AddMessage(fmt.Sprintf("Монеты: %d/%d", p.coinCount, p.maxCoins))
-        p.player.DebugSystem.AddMessage(fmt.Sprintf("Счёт: %d", p.score))
-        p.player.DebugSystem.AddMessage(fmt.Sprintf("Волна: %d", p.enemyCount))
+        p.player.DebugSystem.AddMessage(i18n.T("debug.enemies", len(p.enemies)))
+        p.player.DebugSystem.AddMessage(i18n.T("debug.coins", p.coinCount, p.tuning.Coins.Max))
+        p.player.DebugSystem.AddMessage(i18n.T("debug.score", p.score))
+        p.player.DebugSystem.AddMessage(i18n.T("debug.wave", p.enemyCount))
+        p.player.DebugSystem.AddMessage(i18n.T("debug.level", p.experience.Level, p.experience.XP, p.experience.Next()))
+        p.player.DebugSystem.AddMessage(i18n.T("debug.particles", p.particles.Count()))
     }
 
 	// Обрабатываем взаимодействие с врагами
+	timer = profiler.Begin("enemies")
 	for _, e := range p.enemies {
 		// Обновляем врага, передавая позицию игрока как цель
-		e.Update(p.player.X+10, p.player.Y+10)
+		if !p.inspector.Frozen(e) {
+			e.Update(p.player.X+10, p.player.Y+10)
+		}
 
 		// Вычисляем расстояние между игроком и врагом
 		dx := p.player.X + 10 - e.X
@@ -131,18 +549,33 @@ func (p *PlayState) Update() error {
 		distance := math.Sqrt(dx*dx + dy*dy)
 		
 		// Проверяем столкновение с врагом
-		if distance < 20 && e.Alive {
+		if distance < 20 && e.Alive && !p.god {
 			// Уменьшаем здоровье при контакте с врагом
 			p.player.Health -= 25
+			p.sound.Play(sound.Hurt)
+			p.particles.Burst("hurt", p.player.X+10, p.player.Y+10)
+			p.camera.AddTrauma(0.6)
+			p.camera.Hitstop(6)
 			
 			// Проверяем, умер ли игрок
 			if p.player.Health <= 0 {
 				// Запускаем анимацию смерти
 				p.player.StartDeathAnimation()
+				p.sound.Play(sound.GameOver)
+				
+				// Забег окончен, продолжать его больше нельзя
+				if err := save.Remove(); err != nil {
+					log.Printf("Не удалось удалить сохранение: %v", err)
+				}
 				
-				// Переходим в состояние смерти
-				p.stateMachine.ChangeState("death")
-				return nil
+				// Записываем итоги забега в профиль и передаём их экрану смерти
+				timer.End()
+				result := p.finishRun("death.cause.enemy")
+				return p.stateMachine.ChangeStateWith(StateDeath, result, Transition{
+					Kind:     TransitionIris,
+					Duration: 0.8,
+					Easing:   utils.EaseOutCubic,
+				})
 			}
 			
 			// Отталкиваем игрока от врага
@@ -152,28 +585,57 @@ func (p *PlayState) Update() error {
 			p.player.Y += math.Sin(pushDirection) * pushDistance
 		}
 	}
+	timer.End()
 
 	// Проверяем сбор монеток
+	timer = profiler.Begin("coins_pickup")
 	for i := len(p.coins) - 1; i >= 0; i-- {
 		coin := p.coins[i]
 		// Проверяем коллизию с игроком
 		if coin.Collides(p.player.X, p.player.Y, 20, 20) { // Предполагаемый размер игрока
 			// Увеличиваем счет
-			p.score += 50
+			p.score += p.tuning.Coins.Score
+			p.sound.Play(sound.Coin)
+			p.particles.Burst("coin", coin.GetX()+8, coin.GetY()+8)
+			p.coinsCollected++
 			
 			// Удаляем монетку
 			p.coins = append(p.coins[:i], p.coins[i+1:]...)
 			p.coinCount--
 			
 			// Создаем новую монетку с небольшой задержкой
-			go func() {
-				time.Sleep(2 * time.Second)
-				p.SpawnCoin()
-			}()
+			p.coinRespawns = append(p.coinRespawns, p.tuning.Coins.RespawnDelay)
 		}
 	}
+	
+	// Создаем отложенные монетки, время которых подошло
+	remaining := p.coinRespawns[:0]
+	for _, t := range p.coinRespawns {
+		t -= 1.0 / 60.0
+		if t > 0 {
+			remaining = append(remaining, t)
+		} else {
+			p.SpawnCoin()
+		}
+	}
+	p.coinRespawns = remaining
+
+	// Проверяем сбор сфер опыта
+	for i := len(p.xpOrbs) - 1; i >= 0; i-- {
+		orb := p.xpOrbs[i]
+		if !p.inspector.Frozen(orb) {
+			orb.Update(p.player.X+10, p.player.Y+10)
+		}
+		if orb.Collides(p.player.X, p.player.Y, 20, 20) {
+			p.xpOrbs = append(p.xpOrbs[:i], p.xpOrbs[i+1:]...)
+			p.particles.Burst("xp", orb.GetX(), orb.GetY())
+			p.gainXP(orb.Value)
+		}
+	}
+	timer.End()
 
 	// Подсчитываем живых врагов и проверяем атаки
+	timer = profiler.Begin("collisions")
 	liveEnemies := 0
 	for _, e := range p.enemies {
 		if e.Alive {
@@ -201,48 +663,106 @@ func (p *PlayState) Update() error {
 					attackTop < enemyBottom && attackBottom > enemyTop {
 					// Уничтожаем врага
 					e.Alive = false
+					p.sound.Play(sound.Hit)
+					p.sound.Play(sound.EnemyDeath)
+					p.particles.Burst("hit", e.X+10, e.Y+10)
+					p.particles.Burst("enemy_death", e.X+10, e.Y+10)
+					p.camera.AddTrauma(0.3)
+					p.camera.Hitstop(3)
 					
 					// Увеличиваем счет
-					p.score += 100
+					p.score += p.tuning.Enemy.Score
+					p.kills++
+					p.combo++
+					p.comboTimer = comboWindow
+					
+					// Оставляем сферу опыта на месте врага
+					p.xpOrbs = append(p.xpOrbs, game.NewXPOrb(e.X+10, e.Y+10, p.tuning.Enemy.XP))
 					
 					// С небольшим шансом создаем дополнительную монетку
-					if p.coinCount < p.maxCoins && rand.Float64() < 0.3 {
+					if p.coinCount < p.tuning.Coins.Max && p.rng.Float64() < p.tuning.Enemy.CoinChance {
 						p.SpawnCoin()
 					}
 				}
 			}
 		}
 	}
+	timer.End()
 
-	// Если все враги уничтожены, создаем новую волну
+	// Если все враги уничтожены, после небольшой паузы создаем новую волну
 	if liveEnemies == 0 {
-		// Увеличиваем количество врагов
-		p.enemyCount++
-		
-		// Очищаем список врагов
-		p.enemies = nil
-		
-		// Создаем новых врагов
-		for i := 0; i < p.enemyCount; i++ {
-			p.enemies = append(p.enemies, enemy.NewRandomEdgeEnemy())
+		p.waveBreak += 1.0 / 60.0
+		if p.waveBreak >= wavePause {
+			// Переходим к следующей волне с новыми врагами
+			p.enemyCount++
+			p.spawnWave()
+			
+			// Восстанавливаем немного здоровья при уничтожении всех врагов
+			p.player.Health = math.Min(p.player.Health+p.tuning.Waves.Heal, p.player.MaxHealth)
+			
+			// Создаем бонусную монетку после каждой волны
+			p.SpawnCoin()
 		}
-		
-		// Восстанавливаем немного здоровья при уничтожении всех врагов
-		p.player.Health = math.Min(p.player.Health+10, 100)
-		
-		// Создаем бонусную монетку после каждой волны
-		p.SpawnCoin()
-		
-		// Небольшая пауза между волнами
-		time.Sleep(500 * time.Millisecond)
 	}
 
 	return nil
 }
 
 func (p *PlayState) Draw(screen *ebiten.Image) {
-    // Заполняем фон
-    ebitenutil.DrawRect(screen, 0, 0, 1280, 960, color.RGBA{50, 50, 50, 255})
+    // Заполняем фон; он виден вокруг арены и по краям, когда камера трясётся
+    screen.Fill(color.RGBA{30, 30, 30, 255})
+
+    // Игровой мир рисуется со смещением камеры
+    timer := profiler.Begin("rendering")
+    p.camera.DrawWorld(screen, p.drawWorld)
+    timer.End()
+
+    // Отрисовываем HUD без смещения
+    timer = profiler.Begin("hud_draw")
+    p.hud.Draw(screen)
+    timer.End()
+
+    // Остановленная или ускоренная симуляция видна сразу
+    p.drawTimeControls(screen)
+
+    // Панель инспектора поверх HUD
+    if p.inspecting() {
+        p.inspector.Draw(screen)
+    }
+}
+
+// hudModel собирает всё, что показывает HUD
+func (p *PlayState) hudModel() ui.HUDModel {
+    model := ui.HUDModel{
+        Health:       p.player.Health,
+        MaxHealth:    p.player.MaxHealth,
+        Level:        p.experience.Level,
+        XP:           p.experience.XP,
+        NextXP:       p.experience.Next(),
+        Score:        p.score,
+        DashCharges:  p.player.DashCharges,
+        MaxDashes:    p.player.MaxDashes,
+        DashProgress: p.player.DashProgress(),
+        AttackReady:  p.player.AttackReady(),
+        Wave:         p.enemyCount,
+        Elapsed:      p.elapsed,
+        Combo:        p.combo,
+        ComboLeft:    p.comboTimer / comboWindow,
+    }
+    
+    // Улучшения перечисляются в порядке списка выбора, чтобы HUD не перемешивался
+    for _, choice := range levelUpChoices {
+        if count := p.upgrades[choice.Title]; count > 0 {
+            model.Buffs = append(model.Buffs, ui.Buff{Title: choice.Title, Count: count})
+        }
+    }
+    return model
+}
+
+// drawWorld отрисовывает игровые объекты, частицы и отладочные хитбоксы
+func (p *PlayState) drawWorld(screen *ebiten.Image) {
+    // Заполняем фон арены
+    screen.Fill(color.RGBA{50, 50, 50, 255})
 
     // Отрисовываем игрока
     p.player.Draw(screen)
@@ -252,13 +772,18 @@ func (p *PlayState) Draw(screen *ebiten.Image) {
         coin.Draw(screen)
     }
 
+    // Отрисовываем сферы опыта
+    for _, orb := range p.xpOrbs {
+        orb.Draw(screen)
+    }
+
     // Отрисовываем врагов
     for _, e := range p.enemies {
         e.Draw(screen)
     }
 
-    // Отрисовываем HUD (здоровье и счет)
-    p.hud.Draw(screen, p.player.Health, p.score)
+    // Отрисовываем частицы поверх игровых объектов
+    p.particles.Draw(screen)
 
     // Отрисовываем хитбоксы в режиме отладки
     if p.player.DebugSystem != nil && p.player.DebugSystem.IsEnabled() && p.player.DebugSystem.ShowHitboxes {
@@ -281,9 +806,17 @@ func (p *PlayState) Draw(screen *ebiten.Image) {
             }
         }
     }
+
+    // Выбранный и замороженные объекты обводятся поверх всего
+    if p.inspecting() {
+        p.inspector.DrawWorld(screen)
+    }
 }
 
 // Exit вызывается при выходе из игрового состояния
 func (p *PlayState) Exit() {
-	// Очистка ресурсов при выходе из состояния
+	// Забег, брошенный без сохранения, всё равно пополняет общую статистику
+	if p.runActive {
+		p.abandonRun()
+	}
 }
\ No newline at end of file
@@ -0,0 +1,53 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"superpupergame/profile"
+)
+
+// RunConfig - параметры нового забега, передаваемые игровому состоянию при переходе
+type RunConfig struct {
+	// Seed - зерно генератора случайных чисел; одинаковое зерно повторяет забег
+	Seed uint64
+}
+
+// RunResult - итоги завершённого забега, которые игровое состояние передаёт
+// экранам смерти, таблицы рекордов и статистики
+type RunResult struct {
+	Score      int
+	Wave       int
+	Level      int
+	Kills      int
+	Coins      int
+	DashesUsed int
+	Time       float64 // Длительность забега (в секундах)
+	Seed       uint64
+
+	// CauseOfDeath - ключ строки причины смерти для экрана итогов
+	CauseOfDeath string
+
+	// HighScoreRank - место забега в таблице рекордов (0 - не попал)
+	HighScoreRank int
+
+	// PersonalBest - установил ли забег новый личный рекорд
+	PersonalBest bool
+}
+
+// Run возвращает итоги забега в виде, в котором они записываются в профиль
+func (r RunResult) Run() profile.Run {
+	return profile.Run{
+		Score:      r.Score,
+		Wave:       r.Wave,
+		Level:      r.Level,
+		Seed:       r.Seed,
+		Kills:      r.Kills,
+		Coins:      r.Coins,
+		DashesUsed: r.DashesUsed,
+		PlayTime:   r.Time,
+	}
+}
+
+// Matches сообщает, соответствует ли запись таблицы рекордов этому забегу
+func (r RunResult) Matches(entry profile.HighScore) bool {
+	return r.HighScoreRank > 0 && entry.Seed == r.Seed && entry.Score == r.Score && entry.Wave == r.Wave
+}
@@ -0,0 +1,200 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/config"
+	"superpupergame/i18n"
+	"superpupergame/text"
+	"superpupergame/ui"
+)
+
+// SettingsState реализует экран настроек
+type SettingsState struct {
+	// stateMachine - ссылка на машину состояний для переключения состояний
+	stateMachine *StateMachine
+
+	// settings - редактируемые настройки
+	settings *config.Settings
+
+	// ui - список настроек на экране
+	ui *ui.UI
+}
+
+// settingRow - строка экрана настроек: подпись и виджет, изменяющий значение
+type settingRow struct {
+	label  string
+	widget ui.Widget
+}
+
+// NewSettingsState создаёт экран настроек
+func NewSettingsState(stateMachine *StateMachine, settings *config.Settings) *SettingsState {
+	s := &SettingsState{
+		stateMachine: stateMachine,
+		settings:     settings,
+	}
+
+	scaleNames := make([]string, len(config.WindowScales))
+	for i, scale := range config.WindowScales {
+		scaleNames[i] = fmt.Sprintf("%gx", scale)
+	}
+	resolutionNames := make([]string, len(config.Resolutions))
+	for i, resolution := range config.Resolutions {
+		resolutionNames[i] = resolution.String()
+	}
+	scaleModeNames := make([]string, len(config.ScaleModes))
+	for i, mode := range config.ScaleModes {
+		scaleModeNames[i] = "option.scale." + mode
+	}
+	// Языки подписываются на них самих, чтобы их можно было найти при любом текущем языке
+	languages := make([]string, len(config.Languages))
+	for i, language := range config.Languages {
+		languages[i] = i18n.Default().Name(language)
+	}
+	// Назначения клавиш меняются местами, чтобы одна клавиша не управляла двумя действиями
+	keys := settings.Keys.Bindings()
+
+	rows := []settingRow{
+		{"settings.master_volume", ui.NewSlider(
+			func() float64 { return settings.MasterVolume },
+			func(v float64) { settings.MasterVolume = v })},
+		{"settings.music_volume", ui.NewSlider(
+			func() float64 { return settings.MusicVolume },
+			func(v float64) { settings.MusicVolume = v })},
+		{"settings.sfx_volume", ui.NewSlider(
+			func() float64 { return settings.SFXVolume },
+			func(v float64) { settings.SFXVolume = v })},
+		{"settings.fullscreen", ui.NewCheckbox(
+			func() bool { return settings.Fullscreen },
+			func(v bool) {
+				settings.Fullscreen = v
+				settings.ApplyWindow()
+			})},
+		{"settings.window_scale", ui.NewDropdown(scaleNames,
+			func() int { return max(0, config.IndexOf(config.WindowScales, settings.WindowScale)) },
+			func(i int) {
+				settings.WindowScale = config.WindowScales[i]
+				settings.ApplyWindow()
+			})},
+		{"settings.resolution", ui.NewDropdown(resolutionNames,
+			func() int { return max(0, config.IndexOf(config.Resolutions, settings.Resolution)) },
+			func(i int) {
+				settings.Resolution = config.Resolutions[i]
+				settings.ApplyWindow()
+			})},
+		{"settings.scale_mode", ui.NewDropdown(scaleModeNames,
+			func() int { return max(0, config.IndexOf(config.ScaleModes, settings.ScaleMode)) },
+			func(i int) {
+				settings.ScaleMode = config.ScaleModes[i]
+				settings.ApplyWindow()
+			})},
+		{"settings.vsync", ui.NewCheckbox(
+			func() bool { return settings.VSync },
+			func(v bool) {
+				settings.VSync = v
+				settings.ApplyWindow()
+			})},
+		{"settings.screen_shake", ui.NewSlider(
+			func() float64 { return settings.ScreenShake },
+			func(v float64) { settings.ScreenShake = v })},
+		{"settings.language", ui.NewDropdown(languages,
+			func() int { return max(0, config.IndexOf(config.Languages, settings.Language)) },
+			func(i int) {
+				settings.Language = config.Languages[i]
+				if err := i18n.Default().SetLocale(settings.Language); err != nil {
+					log.Printf("Не удалось переключить язык: %v", err)
+				}
+			})},
+		{"settings.key.up", ui.NewKeyBinder(&settings.Keys.Up, keys)},
+		{"settings.key.down", ui.NewKeyBinder(&settings.Keys.Down, keys)},
+		{"settings.key.left", ui.NewKeyBinder(&settings.Keys.Left, keys)},
+		{"settings.key.right", ui.NewKeyBinder(&settings.Keys.Right, keys)},
+		{"settings.key.dash", ui.NewKeyBinder(&settings.Keys.Dash, keys)},
+		{"settings.hud.health", hudToggle(&settings.HUD.Health)},
+		{"settings.hud.experience", hudToggle(&settings.HUD.Experience)},
+		{"settings.hud.score", hudToggle(&settings.HUD.Score)},
+		{"settings.hud.dash", hudToggle(&settings.HUD.Dash)},
+		{"settings.hud.attack", hudToggle(&settings.HUD.Attack)},
+		{"settings.hud.wave", hudToggle(&settings.HUD.Wave)},
+		{"settings.hud.timer", hudToggle(&settings.HUD.Timer)},
+		{"settings.hud.combo", hudToggle(&settings.HUD.Combo)},
+		{"settings.hud.buffs", hudToggle(&settings.HUD.Buffs)},
+		{"settings.debug", ui.NewCheckbox(
+			func() bool { return settings.Debug.Enabled },
+			func(v bool) { settings.Debug.Enabled = v })},
+		{"settings.debug.fps", ui.NewCheckbox(
+			func() bool { return settings.Debug.ShowFPS },
+			func(v bool) { settings.Debug.ShowFPS = v })},
+		{"settings.debug.hitboxes", ui.NewCheckbox(
+			func() bool { return settings.Debug.ShowHitboxes },
+			func(v bool) { settings.Debug.ShowHitboxes = v })},
+		{"settings.debug.positions", ui.NewCheckbox(
+			func() bool { return settings.Debug.ShowPositions },
+			func(v bool) { settings.Debug.ShowPositions = v })},
+	}
+
+	// Подписи слева, виджеты справа; список прокручивается, если не помещается на экран
+	grid := ui.NewGrid(2, 8)
+	grid.ColumnSpacing = 40
+	grid.Align = ui.AlignStretch
+	for _, row := range rows {
+		grid.Items = append(grid.Items, ui.NewLabel(row.label, text.Options{}), row.widget)
+	}
+
+	column := ui.NewVBox(20,
+		ui.NewLabel("settings.title", text.Options{Size: text.SizeTitle, Shadow: 3}),
+		ui.NewScrollList(640, 480, 0, grid),
+		ui.NewButton(200, 50, "common.back", color.RGBA{100, 100, 100, 255}, s.back),
+	)
+	column.Anchor = ui.AnchorTop
+	column.OffsetY = 70
+	s.ui = ui.NewUI(ui.NewFrame(column))
+
+	return s
+}
+
+// hudToggle создаёт флажок, показывающий или скрывающий элемент HUD
+func hudToggle(value *bool) *ui.Checkbox {
+	return ui.NewCheckbox(
+		func() bool { return *value },
+		func(v bool) { *value = v })
+}
+
+// back сохраняет настройки и возвращается туда, откуда был открыт экран: в главное меню или в меню паузы
+func (s *SettingsState) back() {
+	if err := s.settings.Save(); err != nil {
+		log.Printf("Не удалось сохранить настройки: %v", err)
+	}
+	logTransition(s.stateMachine.Pop())
+}
+
+// Enter вызывается при входе в состояние настроек
+func (s *SettingsState) Enter() {
+	s.ui.Focus(nil)
+}
+
+// Update обрабатывает изменение настроек
+func (s *SettingsState) Update() error {
+	// Escape возвращает в меню, если не идёт назначение клавиши или выбор из списка
+	if !s.ui.Capturing() && ui.BackJustPressed() {
+		s.back()
+		return nil
+	}
+	s.ui.Update()
+	return nil
+}
+
+// Draw отрисовывает экран настроек
+func (s *SettingsState) Draw(screen *ebiten.Image) {
+	fillScreen(screen, color.RGBA{50, 50, 50, 255})
+	s.ui.Draw(screen)
+}
+
+// Exit вызывается при выходе из состояния настроек
+func (s *SettingsState) Exit() {
+	// Очистка ресурсов при выходе из состояния
+}
@@ -0,0 +1,78 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"superpupergame/i18n"
+	"superpupergame/text"
+)
+
+// Клавиши управления симуляцией в режиме отладки
+const (
+	keySimPause  = ebiten.KeyF7  // Остановить или продолжить симуляцию
+	keySimStep   = ebiten.KeyF8  // Сделать один тик, остановив симуляцию
+	keySimSlower = ebiten.KeyF9  // Уменьшить скорость симуляции
+	keySimFaster = ebiten.KeyF10 // Увеличить скорость симуляции
+)
+
+// timeScales - скорости симуляции, которые перебираются клавишами, по возрастанию
+var timeScales = []float64{minTimeScale, 0.25, 0.5, 1, 2, maxTimeScale}
+
+// updateTimeControls обрабатывает отладочные клавиши остановки, шага и скорости симуляции
+func (p *PlayState) updateTimeControls() {
+	if d := p.player.DebugSystem; d == nil || !d.IsEnabled() {
+		return
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(keySimPause):
+		p.simPaused = !p.simPaused
+		p.pendingSteps = 0
+	case inpututil.IsKeyJustPressed(keySimStep):
+		p.stepSimulation(1)
+	case inpututil.IsKeyJustPressed(keySimSlower):
+		p.shiftTimeScale(-1)
+	case inpututil.IsKeyJustPressed(keySimFaster):
+		p.shiftTimeScale(1)
+	}
+}
+
+// stepSimulation останавливает симуляцию и просит сделать n тиков в следующем кадре
+func (p *PlayState) stepSimulation(n int) {
+	p.simPaused = true
+	p.pendingSteps += n
+}
+
+// shiftTimeScale переключает скорость симуляции на соседнюю из timeScales; dir -1 - медленнее
+func (p *PlayState) shiftTimeScale(dir int) {
+	i, found := slices.BinarySearch(timeScales, p.timeScale)
+	switch {
+	case dir < 0:
+		i--
+	case found:
+		i++
+	}
+	p.timeScale = timeScales[max(0, min(i, len(timeScales)-1))]
+}
+
+// drawTimeControls показывает над ареной, что симуляция остановлена или идёт не с обычной скоростью
+func (p *PlayState) drawTimeControls(screen *ebiten.Image) {
+	var label string
+	switch {
+	case p.simPaused:
+		label = i18n.T("debug.sim.paused", p.timeScale)
+	case p.timeScale != 1:
+		label = i18n.T("debug.sim.scale", p.timeScale)
+	default:
+		return
+	}
+	center := float64(screen.Bounds().Dx()) / 2
+	text.Draw(screen, label, center, 10, text.Options{
+		Align:   text.AlignCenter,
+		Color:   color.RGBA{255, 220, 0, 255},
+		Outline: 2,
+	})
+}
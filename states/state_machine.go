@@ -2,7 +2,25 @@
 package states
 
 import (
+	"fmt"
+	"image/color"
+	"log"
+
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"superpupergame/viewport"
+)
+
+// Имена состояний игры
+const (
+	StateMenu        = "menu"
+	StatePlaying     = "playing"
+	StatePaused      = "paused"
+	StateLevelUp     = "levelup"
+	StateSettings    = "settings"
+	StateLeaderboard = "leaderboard"
+	StateStats       = "stats"
+	StateDeath       = "death"
 )
 
 // State - интерфейс для всех состояний игры
@@ -20,21 +38,56 @@ type State interface {
 	Exit()
 }
 
-// StateMachine управляет переключением между состояниями
+// Receiver - состояние, которое принимает данные перехода.
+// Receive вызывается перед Enter с данными, переданными в ChangeState или Push.
+type Receiver interface {
+	Receive(payload any)
+}
+
+// Pauser - состояние, которое нужно уведомлять, когда поверх него открывается другое состояние.
+// В отличие от Exit и Enter, Pause и Resume означают, что состояние остаётся в стеке и продолжит работу.
+type Pauser interface {
+	// Pause вызывается, когда поверх состояния открывается другое
+	Pause()
+	
+	// Resume вызывается, когда состояние снова оказывается на вершине стека
+	Resume()
+}
+
+// stackEntry - состояние в стеке вместе с его именем
+type stackEntry struct {
+	name  string
+	state State
+}
+
+// StateMachine управляет стеком состояний. Обновляется только верхнее состояние,
+// а отрисовываются все состояния стека снизу вверх, поэтому пауза или выбор улучшений
+// рисуются поверх замороженной игры.
 type StateMachine struct {
-	// currentState - текущее активное состояние
-	currentState State
+	// stack - стек активных состояний; последнее - текущее
+	stack []stackEntry
 	
 	// states - карта всех доступных состояний
 	states map[string]State
+	
+	// transition - анимация перехода между экранами; пока она идёт, ввод не обрабатывается
+	transition transitionRunner
+	
+	// width, height - размер экрана на последнем кадре, нужен для снимка экрана при переходе
+	width, height int
 }
 
 // NewStateMachine создает новую машину состояний
 func NewStateMachine() *StateMachine {
 	// Инициализируем машину состояний
-	return &StateMachine{
+	width, height := viewport.Size()
+	sm := &StateMachine{
 		states: make(map[string]State),
+		width:  width,
+		height: height,
 	}
+	sm.registerCommands()
+	return sm
 }
 
 // Add добавляет новое состояние в машину состояний
@@ -43,48 +96,172 @@ func (sm *StateMachine) Add(name string, state State) {
 	sm.states[name] = state
 }
 
-// ChangeState меняет текущее состояние
-func (sm *StateMachine) ChangeState(name string) {
-	// Выходим из текущего состояния, если оно есть
-	if sm.currentState != nil {
-		sm.currentState.Exit()
+// lookup находит состояние по имени
+func (sm *StateMachine) lookup(name string) (State, error) {
+	state, ok := sm.states[name]
+	if !ok {
+		return nil, fmt.Errorf("неизвестное состояние %q", name)
+	}
+	return state, nil
+}
+
+// ChangeState закрывает все состояния стека и переходит в указанное, передавая ему payload.
+// Смена экрана анимируется переходом DefaultTransition.
+func (sm *StateMachine) ChangeState(name string, payload any) error {
+	return sm.ChangeStateWith(name, payload, DefaultTransition)
+}
+
+// ChangeStateWith делает то же, что ChangeState, но с указанной анимацией перехода
+func (sm *StateMachine) ChangeStateWith(name string, payload any, transition Transition) error {
+	state, err := sm.lookup(name)
+	if err != nil {
+		return err
+	}
+	
+	// Запоминаем старый экран для перехода; самому первому экрану переход не нужен
+	if len(sm.stack) > 0 {
+		sm.transition.start(transition, sm.width, sm.height, sm.drawStack)
+	}
+	
+	// Выходим из всех состояний сверху вниз
+	for len(sm.stack) > 0 {
+		top := sm.stack[len(sm.stack)-1]
+		sm.stack = sm.stack[:len(sm.stack)-1]
+		top.state.Exit()
 	}
 	
-	// Получаем новое состояние из карты
-	sm.currentState = sm.states[name]
+	sm.enter(name, state, payload)
+	return nil
+}
+
+// Push открывает состояние поверх текущего, передавая ему payload. Текущее состояние приостанавливается.
+func (sm *StateMachine) Push(name string, payload any) error {
+	state, err := sm.lookup(name)
+	if err != nil {
+		return err
+	}
+	for _, entry := range sm.stack {
+		if entry.state == state {
+			return fmt.Errorf("состояние %q уже открыто", name)
+		}
+	}
 	
-	// Входим в новое состояние
-	if sm.currentState != nil {
-		sm.currentState.Enter()
+	// Приостанавливаем текущее состояние
+	if pauser, ok := sm.current().(Pauser); ok {
+		pauser.Pause()
 	}
+	
+	sm.enter(name, state, payload)
+	return nil
+}
+
+// Pop закрывает верхнее состояние и возобновляет то, что под ним
+func (sm *StateMachine) Pop() error {
+	if len(sm.stack) < 2 {
+		return fmt.Errorf("нельзя закрыть единственное состояние")
+	}
+	
+	top := sm.stack[len(sm.stack)-1]
+	sm.stack = sm.stack[:len(sm.stack)-1]
+	top.state.Exit()
+	
+	// Возобновляем состояние, оказавшееся на вершине
+	if pauser, ok := sm.current().(Pauser); ok {
+		pauser.Resume()
+	}
+	return nil
+}
+
+// enter кладёт состояние на вершину стека и входит в него
+func (sm *StateMachine) enter(name string, state State, payload any) {
+	sm.stack = append(sm.stack, stackEntry{name: name, state: state})
+	
+	if receiver, ok := state.(Receiver); ok {
+		receiver.Receive(payload)
+	}
+	state.Enter()
+}
+
+// current возвращает верхнее состояние стека или nil
+func (sm *StateMachine) current() State {
+	if len(sm.stack) == 0 {
+		return nil
+	}
+	return sm.stack[len(sm.stack)-1].state
+}
+
+// Depth возвращает количество состояний в стеке
+func (sm *StateMachine) Depth() int {
+	return len(sm.stack)
+}
+
+// contains сообщает, находится ли состояние в стеке
+func (sm *StateMachine) contains(state State) bool {
+	for _, entry := range sm.stack {
+		if entry.state == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Transitioning сообщает, идёт ли анимация перехода
+func (sm *StateMachine) Transitioning() bool {
+	return sm.transition.running
 }
 
 // Update обновляет текущее состояние
 func (sm *StateMachine) Update() error {
+	// Во время перехода состояния не обновляются и не получают ввод
+	if sm.transition.running {
+		sm.transition.update()
+		return nil
+	}
+	
 	// Проверяем, что текущее состояние существует
-	if sm.currentState != nil {
+	if current := sm.current(); current != nil {
 		// Обновляем текущее состояние
-		return sm.currentState.Update()
+		return current.Update()
 	}
 	return nil
 }
 
-// Draw отрисовывает текущее состояние
+// Draw отрисовывает все состояния стека снизу вверх, а во время перехода - кадр анимации перехода
 func (sm *StateMachine) Draw(screen *ebiten.Image) {
-	// Проверяем, что текущее состояние существует
-	if sm.currentState != nil {
-		// Отрисовываем текущее состояние
-		sm.currentState.Draw(screen)
+	bounds := screen.Bounds()
+	sm.width, sm.height = bounds.Dx(), bounds.Dy()
+	
+	if sm.transition.running {
+		sm.transition.draw(screen, sm.drawStack)
+		return
+	}
+	sm.drawStack(screen)
+}
+
+// drawStack отрисовывает все состояния стека снизу вверх
+func (sm *StateMachine) drawStack(screen *ebiten.Image) {
+	for _, entry := range sm.stack {
+		entry.state.Draw(screen)
 	}
 }
 
+// logTransition записывает в журнал ошибку перехода там, где её некуда вернуть, например в обработчике кнопки
+func logTransition(err error) {
+	if err != nil {
+		log.Printf("Ошибка перехода между состояниями: %v", err)
+	}
+}
+
+// fillScreen заливает весь экран цветом; полупрозрачный цвет затемняет то, что уже нарисовано
+func fillScreen(screen *ebiten.Image, clr color.Color) {
+	bounds := screen.Bounds()
+	ebitenutil.DrawRect(screen, float64(bounds.Min.X), float64(bounds.Min.Y), float64(bounds.Dx()), float64(bounds.Dy()), clr)
+}
+
 // GetCurrentStateName возвращает имя текущего состояния
 func (sm *StateMachine) GetCurrentStateName() string {
-    // Проходим по карте состояний
-    for name, state := range sm.states {
-        if state == sm.currentState {
-            return name
-        }
-    }
-    return "Неизвестно"
-}
\ No newline at end of file
+	if len(sm.stack) == 0 {
+		return "Неизвестно"
+	}
+	return sm.stack[len(sm.stack)-1].name
+}
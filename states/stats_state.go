@@ -0,0 +1,124 @@
+// Пакет states содержит реализацию состояний игры
+package states
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"superpupergame/i18n"
+	"superpupergame/profile"
+	"superpupergame/text"
+	"superpupergame/ui"
+)
+
+// StatsState показывает итоги забега рядом с общей статистикой профиля
+type StatsState struct {
+	// stateMachine - ссылка на машину состояний для переключения состояний
+	stateMachine *StateMachine
+
+	// profile - профиль игрока с общей статистикой
+	profile *profile.Profile
+
+	// result - итоги забега; nil, если экран открыт без забега
+	result *RunResult
+
+	// ui - кнопки экрана
+	ui *ui.UI
+}
+
+// NewStatsState создаёт экран статистики
+func NewStatsState(stateMachine *StateMachine, profile *profile.Profile) *StatsState {
+	s := &StatsState{
+		stateMachine: stateMachine,
+		profile:      profile,
+	}
+
+	backButton := ui.NewButton(
+		200, 50,
+		"common.back",
+		color.RGBA{100, 100, 100, 255},
+		func() {
+			logTransition(stateMachine.Pop())
+		},
+	)
+	backButton.Anchor = ui.AnchorBottom
+	backButton.OffsetY = -90
+	s.ui = ui.NewUI(ui.NewFrame(backButton))
+
+	return s
+}
+
+// Receive принимает итоги забега
+func (s *StatsState) Receive(payload any) {
+	s.result = nil
+	if result, ok := payload.(RunResult); ok {
+		s.result = &result
+	}
+}
+
+// Enter вызывается при входе в состояние статистики
+func (s *StatsState) Enter() {
+	s.ui.Focus(nil)
+}
+
+// Update обрабатывает навигацию по экрану
+func (s *StatsState) Update() error {
+	if ui.BackJustPressed() {
+		return s.stateMachine.Pop()
+	}
+	s.ui.Update()
+	return nil
+}
+
+// Draw отрисовывает итоги забега и общую статистику
+func (s *StatsState) Draw(screen *ebiten.Image) {
+	fillScreen(screen, color.RGBA{50, 50, 50, 255})
+
+	// Таблица шириной 440 пикселей центрируется по экрану
+	center := float64(screen.Bounds().Dx()) / 2
+	left := center - 220
+	text.Draw(screen, i18n.T("stats.title"), center, 170, text.Options{Size: text.SizeTitle, Align: text.AlignCenter, VAlign: text.AlignEnd, Shadow: 3})
+
+	const rowFormat = "%-16s %12s %12s"
+	text.Draw(screen, fmt.Sprintf(rowFormat, "", i18n.T("stats.this_run"), i18n.T("stats.all_time")), left, 200, text.Options{Font: text.Mono()})
+	ebitenutil.DrawRect(screen, left, 218, 440, 1, color.RGBA{200, 200, 200, 255})
+
+	run := RunResult{}
+	if s.result != nil {
+		run = *s.result
+	}
+	stats := s.profile.Stats
+	rows := [][3]string{
+		{i18n.T("stats.kills"), fmt.Sprint(run.Kills), fmt.Sprint(stats.Kills)},
+		{i18n.T("stats.coins"), fmt.Sprint(run.Coins), fmt.Sprint(stats.Coins)},
+		{i18n.T("stats.dashes"), fmt.Sprint(run.DashesUsed), fmt.Sprint(stats.DashesUsed)},
+		{i18n.T("stats.play_time"), formatDuration(run.Time), formatDuration(stats.PlayTime)},
+		{i18n.T("stats.deaths"), "", fmt.Sprint(stats.Deaths)},
+	}
+	for i, row := range rows {
+		text.Draw(screen, fmt.Sprintf(rowFormat, row[0], row[1], row[2]), left, float64(230+i*30), text.Options{Font: text.Mono()})
+	}
+
+	// Подробности забега
+	if s.result != nil {
+		y := 230 + len(rows)*30 + 30
+		text.Draw(screen, i18n.T("stats.summary", run.Score, run.Wave, run.Level), left, float64(y), text.Options{})
+		text.Draw(screen, i18n.T("stats.cause", i18n.T(run.CauseOfDeath)), left, float64(y+20), text.Options{})
+		text.Draw(screen, i18n.T("stats.seed", run.Seed), left, float64(y+40), text.Options{})
+	}
+
+	s.ui.Draw(screen)
+}
+
+// Exit вызывается при выходе из состояния статистики
+func (s *StatsState) Exit() {
+	// Очистка ресурсов при выходе из состояния
+}
+
+// formatDuration форматирует время в секундах как часы, минуты и секунды
+func formatDuration(seconds float64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
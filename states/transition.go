@@ -0,0 +1,205 @@
+// Пакет states содержит реализацию машины состояний для игры
+package states
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/utils"
+)
+
+// TransitionKind - вид анимации перехода между экранами
+type TransitionKind int
+
+const (
+	TransitionNone     TransitionKind = iota // Мгновенная смена экрана
+	TransitionFade                           // Затемнение старого экрана и проявление нового
+	TransitionWipe                           // Новый экран надвигается слева направо
+	TransitionIris                           // Новый экран раскрывается кругом из центра
+	TransitionPixelate                       // Старый экран распадается на крупные пиксели, новый собирается из них
+)
+
+// maxPixelSize - размер пикселя в середине перехода TransitionPixelate
+const maxPixelSize = 32
+
+// irisSegments - количество отрезков окружности в переходе TransitionIris
+const irisSegments = 64
+
+// Transition описывает анимацию перехода между экранами
+type Transition struct {
+	// Kind - вид анимации
+	Kind TransitionKind
+
+	// Duration - длительность перехода (в секундах)
+	Duration float64
+
+	// Easing - функция сглаживания; nil означает равномерное движение
+	Easing utils.Easing
+}
+
+// DefaultTransition - переход, который используется в ChangeState
+var DefaultTransition = Transition{Kind: TransitionFade, Duration: 0.4, Easing: utils.EaseInOutQuad}
+
+// transitionRunner выполняет переход между снимком старого экрана и отрисовкой нового.
+// Буферы создаются один раз и пересоздаются только при изменении размера экрана.
+type transitionRunner struct {
+	// current - выполняющийся переход
+	current Transition
+
+	// elapsed - время с начала перехода (в секундах)
+	elapsed float64
+
+	// running - идёт ли переход
+	running bool
+
+	// from - снимок экрана до перехода, to - отрисовка нового экрана
+	from, to *ebiten.Image
+
+	// small - буфер для уменьшенной копии экрана в TransitionPixelate
+	small *ebiten.Image
+
+	// vertices, indices - переиспользуемые вершины для TransitionWipe и TransitionIris
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+// ensureBuffers создаёт буферы нужного размера
+func (t *transitionRunner) ensureBuffers(width, height int) {
+	if t.from != nil && t.from.Bounds().Dx() == width && t.from.Bounds().Dy() == height {
+		return
+	}
+	for _, buffer := range []*ebiten.Image{t.from, t.to, t.small} {
+		if buffer != nil {
+			buffer.Deallocate()
+		}
+	}
+	t.from = ebiten.NewImage(width, height)
+	t.to = ebiten.NewImage(width, height)
+	t.small = ebiten.NewImage(width, height)
+}
+
+// start запускает переход; render рисует старый экран в снимок
+func (t *transitionRunner) start(transition Transition, width, height int, render func(screen *ebiten.Image)) {
+	if transition.Kind == TransitionNone || transition.Duration <= 0 {
+		t.running = false
+		return
+	}
+
+	t.ensureBuffers(width, height)
+	t.from.Clear()
+	render(t.from)
+
+	t.current = transition
+	t.elapsed = 0
+	t.running = true
+}
+
+// update продвигает переход на один кадр
+func (t *transitionRunner) update() {
+	t.elapsed += 1.0 / 60.0
+	if t.elapsed >= t.current.Duration {
+		t.running = false
+	}
+}
+
+// progress возвращает сглаженную долю выполнения перехода
+func (t *transitionRunner) progress() float64 {
+	p := math.Min(1, t.elapsed/t.current.Duration)
+	if t.current.Easing != nil {
+		p = t.current.Easing(p)
+	}
+	return p
+}
+
+// draw рисует кадр перехода; render рисует новый экран
+func (t *transitionRunner) draw(screen *ebiten.Image, render func(screen *ebiten.Image)) {
+	bounds := screen.Bounds()
+	t.ensureBuffers(bounds.Dx(), bounds.Dy())
+	t.to.Clear()
+	render(t.to)
+
+	p := t.progress()
+	width, height := float32(bounds.Dx()), float32(bounds.Dy())
+
+	switch t.current.Kind {
+	case TransitionFade:
+		// Первая половина затемняет старый экран, вторая проявляет новый
+		screen.Fill(color.Black)
+		op := &ebiten.DrawImageOptions{}
+		if p < 0.5 {
+			op.ColorScale.Scale(float32(1-2*p), float32(1-2*p), float32(1-2*p), 1)
+			screen.DrawImage(t.from, op)
+		} else {
+			op.ColorScale.Scale(float32(2*p-1), float32(2*p-1), float32(2*p-1), 1)
+			screen.DrawImage(t.to, op)
+		}
+
+	case TransitionWipe:
+		screen.DrawImage(t.from, nil)
+		edge := width * float32(p)
+		t.beginPolygon(edge/2, height/2)
+		t.addPoint(0, 0)
+		t.addPoint(edge, 0)
+		t.addPoint(edge, height)
+		t.addPoint(0, height)
+		t.drawPolygon(screen)
+
+	case TransitionIris:
+		screen.DrawImage(t.from, nil)
+		cx, cy := width/2, height/2
+		radius := float32(math.Hypot(float64(cx), float64(cy)) * p)
+		t.beginPolygon(cx, cy)
+		for i := 0; i < irisSegments; i++ {
+			angle := float64(i) / irisSegments * 2 * math.Pi
+			t.addPoint(cx+radius*float32(math.Cos(angle)), cy+radius*float32(math.Sin(angle)))
+		}
+		t.drawPolygon(screen)
+
+	case TransitionPixelate:
+		// Размер пикселя растёт к середине перехода, где старый экран сменяется новым
+		source := t.from
+		if p >= 0.5 {
+			source = t.to
+		}
+		scale := 1 + (maxPixelSize-1)*(1-math.Abs(2*p-1))
+
+		t.small.Clear()
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(1/scale, 1/scale)
+		op.Filter = ebiten.FilterLinear
+		t.small.DrawImage(source, op)
+
+		region := image.Rect(0, 0, int(math.Ceil(float64(width)/scale)), int(math.Ceil(float64(height)/scale)))
+		op = &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.Filter = ebiten.FilterNearest
+		screen.DrawImage(t.small.SubImage(region).(*ebiten.Image), op)
+	}
+}
+
+// beginPolygon начинает выпуклый многоугольник с центром в (cx, cy)
+func (t *transitionRunner) beginPolygon(cx, cy float32) {
+	t.vertices = t.vertices[:0]
+	t.indices = t.indices[:0]
+	t.addPoint(cx, cy)
+}
+
+// addPoint добавляет вершину многоугольника; текстура нового экрана совпадает с экраном
+func (t *transitionRunner) addPoint(x, y float32) {
+	t.vertices = append(t.vertices, ebiten.Vertex{
+		DstX: x, DstY: y,
+		SrcX: x, SrcY: y,
+		ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1,
+	})
+}
+
+// drawPolygon рисует многоугольник веером треугольников из центра, заполняя его новым экраном
+func (t *transitionRunner) drawPolygon(screen *ebiten.Image) {
+	n := uint16(len(t.vertices) - 1)
+	for i := uint16(1); i <= n; i++ {
+		t.indices = append(t.indices, 0, i, i%n+1)
+	}
+	screen.DrawTriangles(t.vertices, t.indices, t.to, nil)
+}
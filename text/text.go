@@ -0,0 +1,237 @@
+// Пакет text рисует текст шрифтами TrueType/OpenType поверх text/v2:
+// с выравниванием, переносом строк, обводкой и тенью
+package text
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	etext "github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// Размеры шрифта в пикселях
+const (
+	SizeSmall  = 13.0 // Подписи и отладочная информация
+	SizeNormal = 16.0 // Основной текст и кнопки
+	SizeLarge  = 24.0 // Подзаголовки
+	SizeTitle  = 40.0 // Заголовки экранов
+)
+
+// lineSpacing - расстояние между базовыми линиями строк относительно размера шрифта
+const lineSpacing = 1.25
+
+// Align - выравнивание текста относительно точки привязки; значения совпадают с text/v2
+type Align int
+
+const (
+	AlignStart  Align = iota // Точка привязки слева (сверху)
+	AlignCenter              // Точка привязки в центре
+	AlignEnd                 // Точка привязки справа (снизу)
+)
+
+// Font - шрифт, начертания которого создаются один раз для каждого размера
+type Font struct {
+	// source - разобранный файл шрифта
+	source *etext.GoTextFaceSource
+
+	// faces - начертания по размеру
+	faces map[float64]*etext.GoTextFace
+}
+
+// NewFont создаёт шрифт из разобранного файла TTF/OTF
+func NewFont(source *etext.GoTextFaceSource) *Font {
+	return &Font{
+		source: source,
+		faces:  make(map[float64]*etext.GoTextFace),
+	}
+}
+
+// Face возвращает начертание указанного размера
+func (f *Font) Face(size float64) etext.Face {
+	face, ok := f.faces[size]
+	if !ok {
+		face = &etext.GoTextFace{Source: f.source, Size: size}
+		f.faces[size] = face
+	}
+	return face
+}
+
+var (
+	// defaultFont - шрифт интерфейса
+	defaultFont *Font
+
+	// monoFont - моноширинный шрифт для таблиц и отладки
+	monoFont *Font
+)
+
+// SetDefault задаёт шрифт интерфейса
+func SetDefault(f *Font) {
+	defaultFont = f
+}
+
+// Default возвращает шрифт интерфейса
+func Default() *Font {
+	return defaultFont
+}
+
+// SetMono задаёт моноширинный шрифт
+func SetMono(f *Font) {
+	monoFont = f
+}
+
+// Mono возвращает моноширинный шрифт; если он не задан - шрифт интерфейса
+func Mono() *Font {
+	if monoFont == nil {
+		return defaultFont
+	}
+	return monoFont
+}
+
+// Options - параметры отрисовки текста. Нулевое значение - белый текст
+// шрифтом интерфейса размера SizeNormal, привязанный за левый верхний угол.
+type Options struct {
+	Font         *Font       // Шрифт; nil - Default()
+	Size         float64     // Размер в пикселях; 0 - SizeNormal
+	Color        color.Color // Цвет; nil - белый
+	Align        Align       // Выравнивание по горизонтали относительно x
+	VAlign       Align       // Выравнивание по вертикали относительно y
+	MaxWidth     float64     // Ширина, по которой строки переносятся по словам; 0 - без переноса
+	Outline      float64     // Толщина обводки в пикселях; 0 - без обводки
+	OutlineColor color.Color // Цвет обводки; nil - чёрный
+	Shadow       float64     // Смещение тени вправо-вниз в пикселях; 0 - без тени
+	ShadowColor  color.Color // Цвет тени; nil - полупрозрачный чёрный
+}
+
+// font возвращает шрифт с учётом значения по умолчанию
+func (o *Options) font() *Font {
+	if o.Font != nil {
+		return o.Font
+	}
+	return defaultFont
+}
+
+// size возвращает размер с учётом значения по умолчанию
+func (o *Options) size() float64 {
+	if o.Size > 0 {
+		return o.Size
+	}
+	return SizeNormal
+}
+
+// Draw рисует текст в точке (x, y); переводы строк и перенос по MaxWidth дают несколько строк.
+// Пока шрифт не задан, текст рисуется отладочным шрифтом ebiten.
+func Draw(screen *ebiten.Image, s string, x, y float64, opts Options) {
+	font := opts.font()
+	if font == nil {
+		ebitenutil.DebugPrintAt(screen, s, int(x), int(y))
+		return
+	}
+
+	face := font.Face(opts.size())
+	if opts.MaxWidth > 0 {
+		s = wrap(s, face, opts.MaxWidth)
+	}
+
+	// Целые координаты не дают глифам размываться
+	x, y = math.Round(x), math.Round(y)
+	op := &etext.DrawOptions{}
+	op.LineSpacing = opts.size() * lineSpacing
+	op.PrimaryAlign = etext.Align(opts.Align)
+	op.SecondaryAlign = etext.Align(opts.VAlign)
+
+	if opts.Shadow > 0 {
+		drawPass(screen, s, face, op, x+opts.Shadow, y+opts.Shadow, orDefault(opts.ShadowColor, color.RGBA{0, 0, 0, 160}))
+	}
+	if opts.Outline > 0 {
+		outline := orDefault(opts.OutlineColor, color.Black)
+		for dy := -1.0; dy <= 1; dy++ {
+			for dx := -1.0; dx <= 1; dx++ {
+				if dx != 0 || dy != 0 {
+					drawPass(screen, s, face, op, x+dx*opts.Outline, y+dy*opts.Outline, outline)
+				}
+			}
+		}
+	}
+	drawPass(screen, s, face, op, x, y, orDefault(opts.Color, color.White))
+}
+
+// drawPass рисует текст одним цветом со смещением
+func drawPass(screen *ebiten.Image, s string, face etext.Face, op *etext.DrawOptions, x, y float64, c color.Color) {
+	op.GeoM.Reset()
+	op.GeoM.Translate(x, y)
+	op.ColorScale.Reset()
+	op.ColorScale.ScaleWithColor(c)
+	etext.Draw(screen, s, face, op)
+}
+
+// orDefault возвращает c или значение по умолчанию, если цвет не задан
+func orDefault(c, fallback color.Color) color.Color {
+	if c == nil {
+		return fallback
+	}
+	return c
+}
+
+// Print рисует строку белым текстом SizeSmall с левым верхним углом в (x, y) -
+// замена ebitenutil.DebugPrintAt для отладочных надписей
+func Print(screen *ebiten.Image, s string, x, y int) {
+	Draw(screen, s, float64(x), float64(y), Options{Font: Mono(), Size: SizeSmall})
+}
+
+// Measure возвращает ширину и высоту текста с учётом переноса строк
+func Measure(s string, opts Options) (width, height float64) {
+	font := opts.font()
+	if font == nil {
+		// Отладочный шрифт ebiten: 6x16 пикселей на символ
+		lines := strings.Split(s, "\n")
+		for _, line := range lines {
+			width = math.Max(width, float64(utf8.RuneCountInString(line)*6))
+		}
+		return width, float64(len(lines) * 16)
+	}
+
+	face := font.Face(opts.size())
+	if opts.MaxWidth > 0 {
+		s = wrap(s, face, opts.MaxWidth)
+	}
+	return etext.Measure(s, face, opts.size()*lineSpacing)
+}
+
+// Wrap разбивает текст на строки не шире maxWidth; слово длиннее строки остаётся целым
+func Wrap(s string, opts Options) []string {
+	font := opts.font()
+	if font == nil || opts.MaxWidth <= 0 {
+		return strings.Split(s, "\n")
+	}
+	return strings.Split(wrap(s, font.Face(opts.size()), opts.MaxWidth), "\n")
+}
+
+// wrap переносит строки по словам, сохраняя явные переводы строк
+func wrap(s string, face etext.Face, maxWidth float64) string {
+	var out strings.Builder
+	for i, paragraph := range strings.Split(s, "\n") {
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line == "" || etext.Advance(candidate, face) <= maxWidth {
+				line = candidate
+				continue
+			}
+			out.WriteString(line)
+			out.WriteByte('\n')
+			line = word
+		}
+		out.WriteString(line)
+	}
+	return out.String()
+}
@@ -0,0 +1,101 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Параметры следа полосы
+const (
+	trailDelay = 0.5 // Сколько след стоит на месте после уменьшения значения (в секундах)
+	trailSpeed = 0.6 // Скорость, с которой след догоняет значение (доля полосы в секунду)
+)
+
+// Bar - полоса заполнения. При уменьшении значения за ней остаётся след,
+// который ненадолго задерживается и затем догоняет значение, показывая, сколько было потеряно.
+type Bar struct {
+	Element
+
+	// Value, Max - текущее и наибольшее значение
+	Value, Max float64
+
+	// Color - цвет заполненной части
+	Color color.RGBA
+
+	// Colors - цвета заполнения по доле значения: берётся первый порог, которого доля не превышает;
+	// пустой список - всегда Color
+	Colors []BarColor
+
+	// trail - значение, до которого показывается след
+	trail float64
+
+	// hold - сколько ещё секунд след стоит на месте
+	hold float64
+}
+
+// BarColor - цвет полосы, когда доля значения не больше Below
+type BarColor struct {
+	Below float64
+	Color color.RGBA
+}
+
+// NewBar создаёт полосу указанного размера
+func NewBar(width, height float64, clr color.RGBA) *Bar {
+	bar := &Bar{Max: 1, Color: clr}
+	bar.Width, bar.Height = width, height
+	return bar
+}
+
+// Set задаёт значение полосы и продвигает след на один кадр; вызывается каждый кадр
+func (b *Bar) Set(value, maximum float64) {
+	if value < b.Value {
+		b.hold = trailDelay
+	}
+	b.Value, b.Max = value, maximum
+
+	switch {
+	case value >= b.trail:
+		b.trail = value
+	case b.hold > 0:
+		b.hold -= 1.0 / 60.0
+	default:
+		b.trail = max(value, b.trail-maximum*trailSpeed/60.0)
+	}
+}
+
+// ratio возвращает долю value от наибольшего значения в диапазоне [0, 1]
+func (b *Bar) ratio(value float64) float64 {
+	if b.Max <= 0 {
+		return 0
+	}
+	return max(0, min(1, value/b.Max))
+}
+
+// fillColor возвращает цвет заполнения для текущей доли значения
+func (b *Bar) fillColor() color.RGBA {
+	ratio := b.ratio(b.Value)
+	for _, c := range b.Colors {
+		if ratio <= c.Below {
+			return c.Color
+		}
+	}
+	return b.Color
+}
+
+// MinSize возвращает заданный размер полосы
+func (b *Bar) MinSize() (float64, float64) {
+	return b.size(100, 10)
+}
+
+// Draw отрисовывает фон, след, заполнение и рамку
+func (b *Bar) Draw(screen *ebiten.Image) {
+	r := b.Rect
+	x, y, h := float32(r.X), float32(r.Y), float32(r.H)
+	vector.DrawFilledRect(screen, x, y, float32(r.W), h, fieldColor, false)
+	vector.DrawFilledRect(screen, x, y, float32(r.W*b.ratio(b.trail)), h, color.RGBA{230, 230, 230, 255}, false)
+	vector.DrawFilledRect(screen, x, y, float32(r.W*b.ratio(b.Value)), h, b.fillColor(), false)
+	vector.StrokeRect(screen, x, y, float32(r.W), h, 1, borderColor, false)
+}
@@ -3,64 +3,71 @@ package ui
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"image/color"
+	"superpupergame/i18n"
+	"superpupergame/text"
 )
 
 // Button представляет кнопку в пользовательском интерфейсе
 type Button struct {
-	// X - координата X верхнего левого угла кнопки
-	X float64
-	
-	// Y - координата Y верхнего левого угла кнопки
-	Y float64
-	
-	// Width - ширина кнопки
-	Width float64
-	
-	// Height - высота кнопки
-	Height float64
-	
-	// Text - текст на кнопке
+	Element
+
+	// Text - ключ строки текста на кнопке; строка без перевода выводится как есть
 	Text string
-	
+
 	// Color - цвет кнопки
 	Color color.RGBA
-	
+
 	// OnClick - функция, вызываемая при нажатии на кнопку
 	OnClick func()
 }
 
-// NewButton создает новую кнопку
-func NewButton(x, y, width, height float64, text string, color color.RGBA, onClick func()) *Button {
+// NewButton создает новую кнопку указанного размера
+func NewButton(width, height float64, text string, color color.RGBA, onClick func()) *Button {
 	// Создаем и возвращаем новую кнопку
-	return &Button{
-		X:       x,
-		Y:       y,
-		Width:   width,
-		Height:  height,
+	button := &Button{
 		Text:    text,
 		Color:   color,
 		OnClick: onClick,
 	}
+	button.Width, button.Height = width, height
+	return button
 }
 
-// Contains проверяет, содержит ли кнопка указанную точку
-func (b *Button) Contains(x, y int) bool {
-	// Проверяем, находится ли точка внутри кнопки
-	return float64(x) >= b.X && float64(x) <= b.X+b.Width &&
-		float64(y) >= b.Y && float64(y) <= b.Y+b.Height
+// MinSize возвращает размер кнопки; без заданного размера кнопка подстраивается под текст
+func (b *Button) MinSize() (float64, float64) {
+	w, h := text.Measure(i18n.T(b.Text), text.Options{})
+	return b.size(w+32, h+16)
+}
+
+// Activate вызывает обработчик нажатия
+func (b *Button) Activate() {
+	if b.OnClick != nil {
+		b.OnClick()
+	}
 }
 
 // Draw отрисовывает кнопку
 func (b *Button) Draw(screen *ebiten.Image) {
-	// Рисуем прямоугольник кнопки
-	ebitenutil.DrawRect(screen, b.X, b.Y, b.Width, b.Height, b.Color)
-	
-	// Рассчитываем положение текста для центрирования
-	textX := b.X + (b.Width-float64(len(b.Text)*6))/2
-	textY := b.Y + b.Height/2
-	
-	// Отрисовываем текст кнопки
-	ebitenutil.DebugPrintAt(screen, b.Text, int(textX), int(textY))
-}
\ No newline at end of file
+	r := b.Rect
+
+	// Рисуем прямоугольник кнопки; цвет зависит от наведения, нажатия и доступности
+	vector.DrawFilledRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), shade(b.Color, b.State()), false)
+
+	// Рисуем рамку вокруг кнопки в фокусе
+	if b.Focused() {
+		drawFocus(screen, r)
+	}
+
+	// Отрисовываем текст кнопки на текущем языке по центру; нажатая кнопка немного проседает
+	cx, cy := r.center()
+	if b.pressed {
+		cy++
+	}
+	var textColor color.Color = color.White
+	if b.Disabled {
+		textColor = color.RGBA{160, 160, 160, 255}
+	}
+	text.Draw(screen, i18n.T(b.Text), cx, cy, text.Options{Align: text.AlignCenter, VAlign: text.AlignCenter, Color: textColor})
+}
@@ -0,0 +1,60 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Charges - ряд ячеек зарядов: готовые заряды залиты полностью,
+// восстанавливающиеся - на долю готовности
+type Charges struct {
+	Element
+
+	// Count, Max - готовые заряды и их наибольшее количество
+	Count, Max int
+
+	// Progress - готовность восстанавливающихся зарядов (0..1), начиная с ближайшего
+	Progress []float64
+
+	// Color - цвет залитой части ячейки
+	Color color.RGBA
+
+	// CellWidth, CellHeight, Spacing - размер ячейки и расстояние между ячейками
+	CellWidth, CellHeight, Spacing float64
+}
+
+// NewCharges создаёт ряд ячеек зарядов
+func NewCharges(clr color.RGBA) *Charges {
+	return &Charges{Color: clr, CellWidth: 24, CellHeight: 10, Spacing: 6}
+}
+
+// MinSize возвращает размер ряда из Max ячеек
+func (c *Charges) MinSize() (float64, float64) {
+	return c.size(float64(c.Max)*(c.CellWidth+c.Spacing)-c.Spacing, c.CellHeight)
+}
+
+// Draw отрисовывает ячейки слева направо
+func (c *Charges) Draw(screen *ebiten.Image) {
+	y := float32(c.Rect.Y + (c.Rect.H-c.CellHeight)/2)
+	w, h := float32(c.CellWidth), float32(c.CellHeight)
+	for i := 0; i < c.Max; i++ {
+		x := float32(c.Rect.X + float64(i)*(c.CellWidth+c.Spacing))
+		fill := 0.0
+		if i < c.Count {
+			fill = 1
+		} else if j := i - c.Count; j < len(c.Progress) {
+			fill = c.Progress[j]
+		}
+
+		vector.DrawFilledRect(screen, x, y, w, h, fieldColor, false)
+		clr := c.Color
+		if fill < 1 {
+			clr = shade(c.Color, StateDisabled)
+		}
+		vector.DrawFilledRect(screen, x, y, w*float32(fill), h, clr, false)
+		vector.StrokeRect(screen, x, y, w, h, 1, borderColor, false)
+	}
+}
@@ -0,0 +1,55 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/i18n"
+	"superpupergame/text"
+)
+
+// checkboxSize - сторона квадрата флажка
+const checkboxSize = 20
+
+// Checkbox - флажок с подписью "Вкл"/"Выкл" справа
+type Checkbox struct {
+	Element
+
+	// Get, Set - чтение и запись значения
+	Get func() bool
+	Set func(value bool)
+}
+
+// NewCheckbox создаёт флажок
+func NewCheckbox(get func() bool, set func(bool)) *Checkbox {
+	return &Checkbox{Get: get, Set: set}
+}
+
+// MinSize возвращает размер флажка с подписью
+func (c *Checkbox) MinSize() (float64, float64) {
+	on, _ := text.Measure(i18n.T("option.on"), text.Options{})
+	off, _ := text.Measure(i18n.T("option.off"), text.Options{})
+	return c.size(checkboxSize+10+max(on, off), checkboxSize+8)
+}
+
+// Activate переключает значение
+func (c *Checkbox) Activate() {
+	c.Set(!c.Get())
+}
+
+// Draw отрисовывает флажок
+func (c *Checkbox) Draw(screen *ebiten.Image) {
+	r := c.Rect
+	boxY := r.Y + r.H/2 - checkboxSize/2
+	if c.Focused() {
+		drawFocus(screen, r)
+	}
+	vector.StrokeRect(screen, float32(r.X), float32(boxY), checkboxSize, checkboxSize, 2, shade(borderColor, c.State()), false)
+
+	label := i18n.T("option.off")
+	if c.Get() {
+		vector.DrawFilledRect(screen, float32(r.X)+5, float32(boxY)+5, checkboxSize-10, checkboxSize-10, shade(accentColor, c.State()), false)
+		label = i18n.T("option.on")
+	}
+	text.Draw(screen, label, r.X+checkboxSize+10, r.Y+r.H/2, text.Options{VAlign: text.AlignCenter})
+}
@@ -0,0 +1,127 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/i18n"
+	"superpupergame/text"
+)
+
+// Dropdown - выбор одного варианта из раскрывающегося списка.
+// Стрелки влево и вправо перебирают варианты, не раскрывая список.
+type Dropdown struct {
+	Element
+
+	// Items - ключи строк подписей вариантов
+	Items []string
+
+	// Get, Set - чтение и запись индекса выбранного варианта
+	Get func() int
+	Set func(index int)
+
+	// open - раскрыт ли список
+	open bool
+
+	// highlight - подсвеченный вариант раскрытого списка
+	highlight int
+}
+
+// NewDropdown создаёт раскрывающийся список
+func NewDropdown(items []string, get func() int, set func(int)) *Dropdown {
+	return &Dropdown{Items: items, Get: get, Set: set}
+}
+
+// MinSize возвращает размер по самой длинной подписи
+func (d *Dropdown) MinSize() (float64, float64) {
+	w, h := 0.0, 0.0
+	for _, item := range d.Items {
+		iw, ih := text.Measure(i18n.T(item), text.Options{})
+		w, h = max(w, iw), max(h, ih)
+	}
+	return d.size(w+48, h+12)
+}
+
+// Activate раскрывает список
+func (d *Dropdown) Activate() {
+	d.open = true
+	d.highlight = d.Get()
+}
+
+// Adjust выбирает соседний вариант по кругу
+func (d *Dropdown) Adjust(step int) {
+	d.Set((d.Get() + step + len(d.Items)) % len(d.Items))
+}
+
+// Capturing сообщает, раскрыт ли список
+func (d *Dropdown) Capturing() bool {
+	return d.open
+}
+
+// Capture обрабатывает выбор в раскрытом списке; Escape и щелчок мимо списка закрывают его
+func (d *Dropdown) Capture() {
+	switch {
+	case UpJustPressed():
+		d.highlight = (d.highlight - 1 + len(d.Items)) % len(d.Items)
+	case DownJustPressed():
+		d.highlight = (d.highlight + 1) % len(d.Items)
+	case ConfirmJustPressed():
+		d.Set(d.highlight)
+		d.open = false
+	case BackJustPressed():
+		d.open = false
+	}
+
+	cx, cy := ebiten.CursorPosition()
+	x, y := float64(cx), float64(cy)
+	for i := range d.Items {
+		if d.row(i).Contains(x, y) {
+			d.highlight = i
+		}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if d.popup().Contains(x, y) {
+			d.Set(d.highlight)
+		}
+		d.open = false
+	}
+}
+
+// row возвращает область варианта в раскрытом списке
+func (d *Dropdown) row(i int) Rect {
+	r := d.Rect
+	return Rect{X: r.X, Y: r.Y + r.H*float64(i+1), W: r.W, H: r.H}
+}
+
+// popup возвращает область раскрытого списка
+func (d *Dropdown) popup() Rect {
+	r := d.Rect
+	return Rect{X: r.X, Y: r.Y + r.H, W: r.W, H: r.H * float64(len(d.Items))}
+}
+
+// Draw отрисовывает выбранный вариант со стрелкой
+func (d *Dropdown) Draw(screen *ebiten.Image) {
+	r := d.Rect
+	vector.DrawFilledRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), fieldColor, false)
+	vector.StrokeRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), 2, shade(borderColor, d.State()), false)
+	if d.Focused() {
+		drawFocus(screen, r)
+	}
+	text.Draw(screen, i18n.T(d.Items[d.Get()]), r.X+10, r.Y+r.H/2, text.Options{VAlign: text.AlignCenter})
+	text.Draw(screen, "▼", r.X+r.W-10, r.Y+r.H/2, text.Options{Align: text.AlignEnd, VAlign: text.AlignCenter, Size: text.SizeSmall})
+}
+
+// DrawOverlay отрисовывает раскрытый список поверх остального интерфейса
+func (d *Dropdown) DrawOverlay(screen *ebiten.Image) {
+	p := d.popup()
+	vector.DrawFilledRect(screen, float32(p.X), float32(p.Y), float32(p.W), float32(p.H), fieldColor, false)
+	for i, item := range d.Items {
+		row := d.row(i)
+		if i == d.highlight {
+			vector.DrawFilledRect(screen, float32(row.X), float32(row.Y), float32(row.W), float32(row.H), shade(accentColor, StatePressed), false)
+		}
+		text.Draw(screen, i18n.T(item), row.X+10, row.Y+row.H/2, text.Options{VAlign: text.AlignCenter})
+	}
+	vector.StrokeRect(screen, float32(p.X), float32(p.Y), float32(p.W), float32(p.H), 2, focusColor, false)
+}
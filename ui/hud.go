@@ -3,56 +3,177 @@ package ui
 
 import (
 	"fmt"
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/config"
+	"superpupergame/i18n"
+	"superpupergame/text"
 )
 
-// HUD представляет элементы интерфейса во время игры
-type HUD struct {
-	// Здесь могут быть различные настройки HUD
+// HUDModel - всё, что показывает HUD; игровое состояние заполняет его каждый кадр
+type HUDModel struct {
+	// Health, MaxHealth - текущее и наибольшее здоровье
+	Health, MaxHealth float64
+
+	// Level, XP, NextXP - уровень, опыт на уровне и опыт до следующего уровня
+	Level, XP, NextXP int
+
+	// Score - счёт забега
+	Score int
+
+	// DashCharges, MaxDashes - готовые заряды рывка и их наибольшее количество
+	DashCharges, MaxDashes int
+
+	// DashProgress - готовность восстанавливающихся зарядов рывка (0..1), начиная с ближайшего
+	DashProgress []float64
+
+	// AttackReady - готовность атаки после перезарядки (0..1)
+	AttackReady float64
+
+	// Wave - номер волны
+	Wave int
+
+	// Elapsed - время забега (в секундах)
+	Elapsed float64
+
+	// Combo - убийства подряд; HUD показывает серию от двух убийств
+	Combo int
+
+	// ComboLeft - доля времени, оставшегося до обрыва серии (0..1)
+	ComboLeft float64
+
+	// Buffs - улучшения, действующие до конца забега
+	Buffs []Buff
 }
 
-// NewHUD создает новый экземпляр HUD
-func NewHUD() *HUD {
-	// Создаем и возвращаем новый HUD
-	return &HUD{}
+// Buff - улучшение, взятое за уровень, и сколько раз оно взято
+type Buff struct {
+	// Title - ключ строки названия улучшения
+	Title string
+
+	// Count - сколько раз улучшение взято
+	Count int
 }
 
-// Draw отрисовывает все элементы HUD
-func (h *HUD) Draw(screen *ebiten.Image, health float64, score int) {
-	// Отрисовываем полоску здоровья
-	h.DrawHealthBar(screen, 20, 20, 200, 20, health)
-	
-	// Отрисовываем счет
-	scoreText := fmt.Sprintf("Score: %d", score)
-	ebitenutil.DebugPrintAt(screen, scoreText, 20, 50)
+// HUD - элементы интерфейса во время игры: слева здоровье, опыт, счёт, рывки и атака,
+// справа волна, время и серия убийств, внизу слева улучшения
+type HUD struct {
+	// settings - какие элементы показывать; читаются каждый кадр
+	settings *config.HUDSettings
+
+	// root - рамка на весь экран, к краям которой привязаны столбцы HUD
+	root *Frame
+
+	// health, experience, attack, comboTimer - полосы здоровья, опыта, готовности атаки и окна серии
+	health, experience, attack, comboTimer *Bar
+
+	// level, score, wave, timer, combo - подписи
+	level, score, wave, timer, combo *Label
+
+	// dash - заряды рывка
+	dash *Charges
+
+	// experienceRow, dashRow, attackRow, comboColumn - строки, которые скрываются целиком
+	experienceRow, dashRow, attackRow, comboColumn *Box
+
+	// buffs - столбец подписей улучшений
+	buffs *Box
+}
+
+// NewHUD создаёт HUD; settings определяют, какие элементы видны
+func NewHUD(settings *config.HUDSettings) *HUD {
+	outline := text.Options{Outline: 1}
+	h := &HUD{
+		settings:   settings,
+		health:     NewBar(200, 20, color.RGBA{0, 200, 0, 255}),
+		experience: NewBar(200, 8, color.RGBA{80, 200, 255, 255}),
+		attack:     NewBar(80, 8, color.RGBA{255, 160, 60, 255}),
+		comboTimer: NewBar(120, 6, color.RGBA{255, 220, 80, 255}),
+		level:      NewLabel("hud.level", text.Options{Size: text.SizeSmall, Outline: 1}),
+		score:      NewLabel("hud.score", text.Options{Size: text.SizeLarge, Outline: 1}),
+		wave:       NewLabel("hud.wave", text.Options{Size: text.SizeLarge, Outline: 1}),
+		timer:      NewLabel("hud.time", outline),
+		combo:      NewLabel("hud.combo", text.Options{Size: text.SizeLarge, Outline: 1, Color: color.RGBA{255, 220, 80, 255}}),
+		dash:       NewCharges(color.RGBA{255, 255, 0, 255}),
+		buffs:      NewVBox(4),
+	}
+	h.health.Colors = []BarColor{
+		{Below: 0.3, Color: color.RGBA{200, 0, 0, 255}},
+		{Below: 0.7, Color: color.RGBA{200, 200, 0, 255}},
+	}
+
+	h.experienceRow = NewHBox(10, h.experience, h.level)
+	h.dashRow = NewHBox(10, NewLabel("hud.dash", outline), h.dash)
+	h.attackRow = NewHBox(10, NewLabel("hud.attack", outline), h.attack)
+	h.comboColumn = NewVBox(4, h.combo, h.comboTimer)
+	h.comboColumn.Align = AlignEnd
+
+	left := NewVBox(8, h.health, h.experienceRow, h.score, h.dashRow, h.attackRow)
+	left.Align = AlignStart
+	left.OffsetX, left.OffsetY = 20, 20
+
+	right := NewVBox(4, h.wave, h.timer, h.comboColumn)
+	right.Align = AlignEnd
+	right.Anchor = AnchorTopRight
+	right.OffsetX, right.OffsetY = -20, 20
+
+	h.buffs.Align = AlignStart
+	h.buffs.Anchor = AnchorBottomLeft
+	h.buffs.OffsetX, h.buffs.OffsetY = 20, -20
+
+	h.root = NewFrame(left, right, h.buffs)
+	return h
 }
 
-// DrawHealthBar отрисовывает полоску здоровья
-func (h *HUD) DrawHealthBar(screen *ebiten.Image, x, y, width, height float64, health float64) {
-	// Фон полоски здоровья (серый)
-	ebitenutil.DrawRect(screen, x, y, width, height, color.RGBA{100, 100, 100, 255})
-	
-	// Заполнение полоски здоровья (зеленый -> желтый -> красный)
-	healthWidth := (health / 100) * width
-	var healthColor color.RGBA
-	
-	// Выбираем цвет в зависимости от уровня здоровья
-	if health > 70 {
-		healthColor = color.RGBA{0, 200, 0, 255} // Зеленый
-	} else if health > 30 {
-		healthColor = color.RGBA{200, 200, 0, 255} // Желтый
-	} else {
-		healthColor = color.RGBA{200, 0, 0, 255} // Красный
+// Update переносит модель в виджеты и продвигает след полосы здоровья; вызывается каждый кадр
+func (h *HUD) Update(m HUDModel) {
+	h.health.Set(m.Health, m.MaxHealth)
+	h.experience.Set(float64(m.XP), float64(m.NextXP))
+	h.attack.Set(m.AttackReady, 1)
+	h.comboTimer.Set(m.ComboLeft, 1)
+
+	h.level.Args = []any{m.Level, m.XP, m.NextXP}
+	h.score.Args = []any{m.Score}
+	h.wave.Args = []any{m.Wave}
+	h.timer.Args = []any{formatTime(m.Elapsed)}
+	h.combo.Args = []any{m.Combo}
+
+	h.dash.Count, h.dash.Max = m.DashCharges, m.MaxDashes
+	h.dash.Progress = m.DashProgress
+
+	// Подписи улучшений создаются по мере надобности и переиспользуются
+	for len(h.buffs.Items) < len(m.Buffs) {
+		h.buffs.Items = append(h.buffs.Items, NewLabel("hud.buff", text.Options{Size: text.SizeSmall, Outline: 1}))
 	}
-	
-	// Отрисовываем заполненную часть полоски здоровья
-	ebitenutil.DrawRect(screen, x, y, healthWidth, height, healthColor)
-	
-	// Рисуем рамку полоски здоровья
-	ebitenutil.DrawLine(screen, x, y, x+width, y, color.RGBA{200, 200, 200, 255})
-	ebitenutil.DrawLine(screen, x, y+height, x+width, y+height, color.RGBA{200, 200, 200, 255})
-	ebitenutil.DrawLine(screen, x, y, x, y+height, color.RGBA{200, 200, 200, 255})
-	ebitenutil.DrawLine(screen, x+width, y, x+width, y+height, color.RGBA{200, 200, 200, 255})
-}
\ No newline at end of file
+	for i, item := range h.buffs.Items {
+		label := item.(*Label)
+		label.Hidden = i >= len(m.Buffs)
+		if !label.Hidden {
+			label.Args = []any{i18n.T(m.Buffs[i].Title), m.Buffs[i].Count}
+		}
+	}
+
+	h.health.Hidden = !h.settings.Health
+	h.experienceRow.Hidden = !h.settings.Experience
+	h.score.Hidden = !h.settings.Score
+	h.dashRow.Hidden = !h.settings.Dash
+	h.attackRow.Hidden = !h.settings.Attack
+	h.wave.Hidden = !h.settings.Wave
+	h.timer.Hidden = !h.settings.Timer
+	h.comboColumn.Hidden = !h.settings.Combo || m.Combo < 2
+	h.buffs.Hidden = !h.settings.Buffs
+}
+
+// Draw отрисовывает HUD поверх игрового мира, привязывая его к краям экрана
+func (h *HUD) Draw(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	h.root.Layout(Rect{X: float64(bounds.Min.X), Y: float64(bounds.Min.Y), W: float64(bounds.Dx()), H: float64(bounds.Dy())})
+	h.root.Draw(screen)
+}
+
+// formatTime возвращает время в виде "м:сс"
+func formatTime(seconds float64) string {
+	total := int(seconds)
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
@@ -0,0 +1,67 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// GamepadButtonJustPressed сообщает, была ли кнопка нажата на любом подключённом геймпаде
+func GamepadButtonJustPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpJustPressed - навигация вверх с клавиатуры или геймпада
+func UpJustPressed() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) ||
+		GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftTop)
+}
+
+// DownJustPressed - навигация вниз с клавиатуры или геймпада
+func DownJustPressed() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) ||
+		GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftBottom)
+}
+
+// LeftJustPressed - навигация влево с клавиатуры или геймпада
+func LeftJustPressed() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) ||
+		GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftLeft)
+}
+
+// RightJustPressed - навигация вправо с клавиатуры или геймпада
+func RightJustPressed() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) ||
+		GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftRight)
+}
+
+// ConfirmJustPressed - подтверждение с клавиатуры или геймпада
+func ConfirmJustPressed() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyEnter) ||
+		GamepadButtonJustPressed(ebiten.StandardGamepadButtonRightBottom) ||
+		GamepadButtonJustPressed(ebiten.StandardGamepadButtonCenterRight)
+}
+
+// BackJustPressed - отмена или возврат с клавиатуры или геймпада
+func BackJustPressed() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		GamepadButtonJustPressed(ebiten.StandardGamepadButtonRightRight)
+}
+
+// RepeatPressed сообщает о нажатии клавиши с автоповтором при удержании
+func RepeatPressed(key ebiten.Key) bool {
+	const (
+		delay    = 30
+		interval = 3
+	)
+	d := inpututil.KeyPressDuration(key)
+	if d == 1 {
+		return true
+	}
+	return d >= delay && (d-delay)%interval == 0
+}
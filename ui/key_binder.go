@@ -0,0 +1,83 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/config"
+	"superpupergame/i18n"
+	"superpupergame/text"
+)
+
+// KeyBinder - назначение клавиши: после нажатия ждёт новую клавишу
+type KeyBinder struct {
+	Element
+
+	// Key - назначенная клавиша
+	Key *ebiten.Key
+
+	// Group - назначения всех действий, включая Key; клавиша, занятая другим действием,
+	// переходит к этому, а другое действие получает прежнюю клавишу этого
+	Group []*ebiten.Key
+
+	// listening - ожидается ли нажатие новой клавиши
+	listening bool
+}
+
+// NewKeyBinder создаёт назначение клавиши; group - назначения всех действий, среди которых клавиши не повторяются
+func NewKeyBinder(key *ebiten.Key, group []*ebiten.Key) *KeyBinder {
+	return &KeyBinder{Key: key, Group: group}
+}
+
+// MinSize возвращает размер по подсказке, которая длиннее имени клавиши
+func (k *KeyBinder) MinSize() (float64, float64) {
+	w, h := text.Measure(i18n.T("option.press_key"), text.Options{})
+	return k.size(w+20, h+12)
+}
+
+// Activate начинает ожидание новой клавиши
+func (k *KeyBinder) Activate() {
+	k.listening = true
+}
+
+// Capturing сообщает, ожидается ли нажатие новой клавиши
+func (k *KeyBinder) Capturing() bool {
+	return k.listening
+}
+
+// Capture назначает первую нажатую клавишу; Escape отменяет назначение,
+// остальные зарезервированные клавиши (консоль, отладка) не назначаются
+func (k *KeyBinder) Capture() {
+	for _, key := range inpututil.AppendJustPressedKeys(nil) {
+		if key == ebiten.KeyEscape {
+			k.listening = false
+			return
+		}
+		if !config.ValidKey(key) {
+			continue
+		}
+		for _, other := range k.Group {
+			if other != k.Key && *other == key {
+				*other = *k.Key
+			}
+		}
+		*k.Key = key
+		k.listening = false
+		return
+	}
+}
+
+// Draw отрисовывает имя назначенной клавиши
+func (k *KeyBinder) Draw(screen *ebiten.Image) {
+	r := k.Rect
+	vector.StrokeRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), 1, shade(borderColor, k.State()), false)
+	if k.Focused() {
+		drawFocus(screen, r)
+	}
+	label := k.Key.String()
+	if k.listening {
+		label = i18n.T("option.press_key")
+	}
+	text.Draw(screen, label, r.X+10, r.Y+r.H/2, text.Options{VAlign: text.AlignCenter})
+}
@@ -0,0 +1,59 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"superpupergame/i18n"
+	"superpupergame/text"
+)
+
+// Label - надпись; текст переводится при каждой отрисовке, поэтому смена языка видна сразу
+type Label struct {
+	Element
+
+	// Text - ключ строки надписи; строка без перевода выводится как есть
+	Text string
+
+	// Args - аргументы, подставляемые в строку
+	Args []any
+
+	// Plural - строка с формами множественного числа; форма выбирается по первому аргументу
+	Plural bool
+
+	// Style - шрифт, размер, цвет и выравнивание; VAlign не учитывается - текст центрируется по высоте
+	Style text.Options
+}
+
+// NewLabel создаёт надпись с указанным оформлением
+func NewLabel(key string, style text.Options) *Label {
+	return &Label{Text: key, Style: style}
+}
+
+// String возвращает переведённый текст надписи
+func (l *Label) String() string {
+	if l.Plural && len(l.Args) > 0 {
+		if n, ok := l.Args[0].(int); ok {
+			return i18n.N(l.Text, n, l.Args...)
+		}
+	}
+	return i18n.T(l.Text, l.Args...)
+}
+
+// MinSize возвращает размер текста
+func (l *Label) MinSize() (float64, float64) {
+	return l.size(text.Measure(l.String(), l.Style))
+}
+
+// Draw отрисовывает надпись внутри своей области
+func (l *Label) Draw(screen *ebiten.Image) {
+	style := l.Style
+	style.VAlign = text.AlignCenter
+	x := l.Rect.X
+	switch style.Align {
+	case text.AlignCenter:
+		x += l.Rect.W / 2
+	case text.AlignEnd:
+		x += l.Rect.W
+	}
+	text.Draw(screen, l.String(), x, l.Rect.Y+l.Rect.H/2, style)
+}
@@ -0,0 +1,294 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Box - контейнер, который выстраивает виджеты в столбец или в строку
+type Box struct {
+	Element
+
+	// Items - дочерние виджеты в порядке следования
+	Items []Widget
+
+	// Vertical - выстраивать виджеты сверху вниз, а не слева направо
+	Vertical bool
+
+	// Spacing - расстояние между соседними виджетами
+	Spacing float64
+
+	// Padding - отступы от краёв контейнера
+	Padding Insets
+
+	// Align - выравнивание виджетов поперёк направления контейнера
+	Align Align
+}
+
+// NewVBox создаёт столбец виджетов, выровненных по центру
+func NewVBox(spacing float64, items ...Widget) *Box {
+	return &Box{Items: items, Vertical: true, Spacing: spacing, Align: AlignCenter}
+}
+
+// NewHBox создаёт строку виджетов, выровненных по центру
+func NewHBox(spacing float64, items ...Widget) *Box {
+	return &Box{Items: items, Spacing: spacing, Align: AlignCenter}
+}
+
+// Children возвращает дочерние виджеты
+func (b *Box) Children() []Widget {
+	return b.Items
+}
+
+// MinSize складывает размеры виджетов вдоль контейнера и берёт наибольший поперёк
+func (b *Box) MinSize() (float64, float64) {
+	main, cross := 0.0, 0.0
+	count := 0
+	for _, item := range b.Items {
+		if item.Base().Hidden {
+			continue
+		}
+		w, h := item.MinSize()
+		if !b.Vertical {
+			w, h = h, w
+		}
+		main += h
+		cross = max(cross, w)
+		count++
+	}
+	if count > 1 {
+		main += b.Spacing * float64(count-1)
+	}
+
+	w, h := cross, main
+	if !b.Vertical {
+		w, h = main, cross
+	}
+	return b.size(w+b.Padding.Left+b.Padding.Right, h+b.Padding.Top+b.Padding.Bottom)
+}
+
+// Layout располагает виджеты один за другим от начала контейнера
+func (b *Box) Layout(r Rect) {
+	b.Rect = r
+	inner := r.Inset(b.Padding)
+	pos := inner.Y
+	if !b.Vertical {
+		pos = inner.X
+	}
+
+	for _, item := range b.Items {
+		if item.Base().Hidden {
+			continue
+		}
+		w, h := item.MinSize()
+		if b.Vertical {
+			x, w := alignSpan(inner.X, inner.W, w, b.Align)
+			item.Layout(Rect{X: x, Y: pos, W: w, H: h})
+			pos += h + b.Spacing
+		} else {
+			y, h := alignSpan(inner.Y, inner.H, h, b.Align)
+			item.Layout(Rect{X: pos, Y: y, W: w, H: h})
+			pos += w + b.Spacing
+		}
+	}
+}
+
+// Draw отрисовывает видимые виджеты
+func (b *Box) Draw(screen *ebiten.Image) {
+	drawItems(screen, b.Items)
+}
+
+// Grid - контейнер, который раскладывает виджеты по ячейкам таблицы построчно
+type Grid struct {
+	Element
+
+	// Items - дочерние виджеты слева направо и сверху вниз
+	Items []Widget
+
+	// Columns - количество столбцов
+	Columns int
+
+	// ColumnSpacing, RowSpacing - расстояния между столбцами и строками
+	ColumnSpacing, RowSpacing float64
+
+	// Padding - отступы от краёв контейнера
+	Padding Insets
+
+	// Align - выравнивание виджета по горизонтали внутри ячейки; по вертикали виджеты центрируются
+	Align Align
+}
+
+// NewGrid создаёт таблицу с указанным числом столбцов
+func NewGrid(columns int, spacing float64, items ...Widget) *Grid {
+	return &Grid{Items: items, Columns: columns, ColumnSpacing: spacing, RowSpacing: spacing}
+}
+
+// Children возвращает дочерние виджеты
+func (g *Grid) Children() []Widget {
+	return g.Items
+}
+
+// cells возвращает ширины столбцов и высоты строк по размерам видимых виджетов
+func (g *Grid) cells() (columns, rows []float64) {
+	columns = make([]float64, max(1, g.Columns))
+	i := 0
+	for _, item := range g.Items {
+		if item.Base().Hidden {
+			continue
+		}
+		w, h := item.MinSize()
+		column, row := i%len(columns), i/len(columns)
+		if row >= len(rows) {
+			rows = append(rows, 0)
+		}
+		columns[column] = max(columns[column], w)
+		rows[row] = max(rows[row], h)
+		i++
+	}
+	return columns, rows
+}
+
+// MinSize возвращает размер таблицы по самым широким столбцам и самым высоким строкам
+func (g *Grid) MinSize() (float64, float64) {
+	columns, rows := g.cells()
+	w := sum(columns) + g.ColumnSpacing*float64(max(0, len(columns)-1))
+	h := sum(rows) + g.RowSpacing*float64(max(0, len(rows)-1))
+	return g.size(w+g.Padding.Left+g.Padding.Right, h+g.Padding.Top+g.Padding.Bottom)
+}
+
+// Layout располагает виджеты по ячейкам
+func (g *Grid) Layout(r Rect) {
+	g.Rect = r
+	inner := r.Inset(g.Padding)
+	columns, rows := g.cells()
+
+	i := 0
+	y := inner.Y
+	for _, item := range g.Items {
+		if item.Base().Hidden {
+			continue
+		}
+		column, row := i%len(columns), i/len(columns)
+		if column == 0 && row > 0 {
+			y += rows[row-1] + g.RowSpacing
+		}
+		x := inner.X
+		for _, width := range columns[:column] {
+			x += width + g.ColumnSpacing
+		}
+
+		w, h := item.MinSize()
+		x, w = alignSpan(x, columns[column], w, g.Align)
+		cellY, h := alignSpan(y, rows[row], h, AlignCenter)
+		item.Layout(Rect{X: x, Y: cellY, W: w, H: h})
+		i++
+	}
+}
+
+// Draw отрисовывает видимые виджеты
+func (g *Grid) Draw(screen *ebiten.Image) {
+	drawItems(screen, g.Items)
+}
+
+// Frame - контейнер, внутри которого каждый виджет привязан к краю, углу или центру
+// по своему полю Anchor. Обычно это корень экрана.
+type Frame struct {
+	Element
+
+	// Items - дочерние виджеты; рисуются по порядку, последний - сверху
+	Items []Widget
+
+	// Padding - отступы от краёв рамки
+	Padding Insets
+
+	// Background - цвет фона; nil - без фона
+	Background color.Color
+}
+
+// NewFrame создаёт рамку с виджетами
+func NewFrame(items ...Widget) *Frame {
+	return &Frame{Items: items}
+}
+
+// Children возвращает дочерние виджеты
+func (f *Frame) Children() []Widget {
+	return f.Items
+}
+
+// MinSize возвращает размер, в который помещается самый большой виджет
+func (f *Frame) MinSize() (float64, float64) {
+	w, h := 0.0, 0.0
+	for _, item := range f.Items {
+		if item.Base().Hidden {
+			continue
+		}
+		iw, ih := item.MinSize()
+		w, h = max(w, iw), max(h, ih)
+	}
+	return f.size(w+f.Padding.Left+f.Padding.Right, h+f.Padding.Top+f.Padding.Bottom)
+}
+
+// Layout располагает виджеты по их точкам привязки
+func (f *Frame) Layout(r Rect) {
+	f.Rect = r
+	inner := r.Inset(f.Padding)
+	for _, item := range f.Items {
+		base := item.Base()
+		if base.Hidden {
+			continue
+		}
+		if base.Anchor == AnchorFill {
+			item.Layout(inner)
+			continue
+		}
+
+		w, h := item.MinSize()
+		column, row := int(base.Anchor)%3, int(base.Anchor)/3
+		x := inner.X + (inner.W-w)*float64(column)/2 + base.OffsetX
+		y := inner.Y + (inner.H-h)*float64(row)/2 + base.OffsetY
+		item.Layout(Rect{X: x, Y: y, W: w, H: h})
+	}
+}
+
+// Draw отрисовывает фон и видимые виджеты
+func (f *Frame) Draw(screen *ebiten.Image) {
+	if f.Background != nil {
+		vector.DrawFilledRect(screen, float32(f.Rect.X), float32(f.Rect.Y), float32(f.Rect.W), float32(f.Rect.H), f.Background, false)
+	}
+	drawItems(screen, f.Items)
+}
+
+// alignSpan располагает отрезок длины size внутри отрезка [start, start+space)
+func alignSpan(start, space, size float64, align Align) (float64, float64) {
+	switch align {
+	case AlignCenter:
+		return start + (space-size)/2, size
+	case AlignEnd:
+		return start + space - size, size
+	case AlignStretch:
+		return start, space
+	default:
+		return start, size
+	}
+}
+
+// drawItems отрисовывает видимые виджеты по порядку
+func drawItems(screen *ebiten.Image, items []Widget) {
+	for _, item := range items {
+		if !item.Base().Hidden {
+			item.Draw(screen)
+		}
+	}
+}
+
+// sum возвращает сумму значений
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
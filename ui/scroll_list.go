@@ -0,0 +1,115 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// scrollBarWidth - ширина полосы прокрутки справа от списка
+const scrollBarWidth = 6
+
+// ScrollList - столбец виджетов с прокруткой; всё, что не помещается в Height, обрезается.
+// Список прокручивается колесом мыши и сам показывает виджет, получивший фокус.
+type ScrollList struct {
+	Element
+
+	// Items - дочерние виджеты сверху вниз
+	Items []Widget
+
+	// Spacing - расстояние между соседними виджетами
+	Spacing float64
+
+	// WheelStep - прокрутка за одно деление колеса мыши
+	WheelStep float64
+
+	// offset - насколько содержимое сдвинуто вверх
+	offset float64
+
+	// content - высота всего содержимого
+	content float64
+}
+
+// NewScrollList создаёт список прокрутки указанного размера
+func NewScrollList(width, height, spacing float64, items ...Widget) *ScrollList {
+	list := &ScrollList{Items: items, Spacing: spacing, WheelStep: 40}
+	list.Width, list.Height = width, height
+	return list
+}
+
+// Children возвращает дочерние виджеты
+func (l *ScrollList) Children() []Widget {
+	return l.Items
+}
+
+// MinSize возвращает размер списка; без заданной высоты список вмещает всё содержимое
+func (l *ScrollList) MinSize() (float64, float64) {
+	w, h := 0.0, 0.0
+	for _, item := range l.Items {
+		if item.Base().Hidden {
+			continue
+		}
+		iw, ih := item.MinSize()
+		w = max(w, iw)
+		h += ih + l.Spacing
+	}
+	return l.size(w+scrollBarWidth*2, max(0, h-l.Spacing))
+}
+
+// Layout располагает виджеты столбцом со сдвигом прокрутки; виджеты растягиваются по ширине списка
+func (l *ScrollList) Layout(r Rect) {
+	l.Rect = r
+	y := r.Y - l.offset
+	l.content = 0
+	for _, item := range l.Items {
+		if item.Base().Hidden {
+			continue
+		}
+		_, h := item.MinSize()
+		item.Layout(Rect{X: r.X, Y: y, W: r.W - scrollBarWidth*2, H: h})
+		y += h + l.Spacing
+		l.content += h + l.Spacing
+	}
+	l.content = max(0, l.content-l.Spacing)
+	l.clamp()
+}
+
+// Scroll сдвигает содержимое на dy пикселей
+func (l *ScrollList) Scroll(dy float64) {
+	l.offset += dy
+	l.clamp()
+}
+
+// ScrollTo прокручивает список так, чтобы область r была видна целиком
+func (l *ScrollList) ScrollTo(r Rect) {
+	if r.Y < l.Rect.Y {
+		l.Scroll(r.Y - l.Rect.Y)
+	} else if bottom, visible := r.Y+r.H, l.Rect.Y+l.Rect.H; bottom > visible {
+		l.Scroll(bottom - visible)
+	}
+}
+
+// clamp не даёт прокрутить список дальше содержимого
+func (l *ScrollList) clamp() {
+	l.offset = max(0, min(l.offset, l.content-l.Rect.H))
+}
+
+// Draw отрисовывает видимую часть виджетов и полосу прокрутки
+func (l *ScrollList) Draw(screen *ebiten.Image) {
+	r := l.Rect
+
+	// Рамка фокуса выходит за виджет на 2 пикселя, поэтому область отсечения чуть шире
+	clip := image.Rect(int(r.X)-3, int(r.Y)-3, int(r.X+r.W)+3, int(r.Y+r.H)+3)
+	drawItems(screen.SubImage(clip).(*ebiten.Image), l.Items)
+
+	if l.content <= r.H {
+		return
+	}
+	barX := float32(r.X + r.W - scrollBarWidth)
+	thumbH := r.H * r.H / l.content
+	thumbY := r.Y + (r.H-thumbH)*l.offset/(l.content-r.H)
+	vector.DrawFilledRect(screen, barX, float32(r.Y), scrollBarWidth, float32(r.H), fieldColor, false)
+	vector.DrawFilledRect(screen, barX, float32(thumbY), scrollBarWidth, float32(thumbH), borderColor, false)
+}
@@ -0,0 +1,74 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/text"
+)
+
+// sliderValueWidth - место справа от полосы под значение в процентах
+const sliderValueWidth = 60
+
+// Slider - ползунок со значением от 0 до 1
+type Slider struct {
+	Element
+
+	// Step - шаг изменения с клавиатуры и геймпада
+	Step float64
+
+	// Get, Set - чтение и запись значения
+	Get func() float64
+	Set func(value float64)
+}
+
+// NewSlider создаёт ползунок с шагом 0.1
+func NewSlider(get func() float64, set func(float64)) *Slider {
+	return &Slider{Step: 0.1, Get: get, Set: set}
+}
+
+// MinSize возвращает размер ползунка
+func (s *Slider) MinSize() (float64, float64) {
+	return s.size(200, 28)
+}
+
+// Activate у ползунка ничего не делает
+func (s *Slider) Activate() {}
+
+// Adjust сдвигает ползунок на один шаг
+func (s *Slider) Adjust(step int) {
+	s.setValue(s.Get() + float64(step)*s.Step)
+}
+
+// Drag устанавливает значение по положению курсора на полосе
+func (s *Slider) Drag(x, y float64) {
+	s.setValue((x - s.Rect.X) / s.barWidth())
+}
+
+// barWidth возвращает ширину полосы без подписи значения
+func (s *Slider) barWidth() float64 {
+	return max(1, s.Rect.W-sliderValueWidth)
+}
+
+// setValue ограничивает значение диапазоном [0, 1] и округляет его до сотых,
+// чтобы шаги не накапливали погрешность
+func (s *Slider) setValue(value float64) {
+	value = math.Round(value*100) / 100
+	s.Set(math.Max(0, math.Min(1, value)))
+}
+
+// Draw отрисовывает полосу ползунка и значение в процентах
+func (s *Slider) Draw(screen *ebiten.Image) {
+	r := s.Rect
+	if s.Focused() {
+		drawFocus(screen, r)
+	}
+	barY := float32(r.Y + r.H/2 - 4)
+	barWidth := s.barWidth()
+	vector.DrawFilledRect(screen, float32(r.X), barY, float32(barWidth), 8, disabledColor, false)
+	vector.DrawFilledRect(screen, float32(r.X), barY, float32(barWidth*s.Get()), 8, shade(accentColor, s.State()), false)
+	text.Draw(screen, fmt.Sprintf("%d%%", int(s.Get()*100+0.5)), r.X+barWidth+10, r.Y+r.H/2, text.Options{VAlign: text.AlignCenter})
+}
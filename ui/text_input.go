@@ -0,0 +1,179 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"superpupergame/text"
+)
+
+// gamepadAlphabet - символы, которые перебираются крестовиной геймпада
+var gamepadAlphabet = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 ")
+
+// TextInput - однострочное поле ввода текста.
+// Нажатие на поле начинает ввод, Enter подтверждает его, Escape или щелчок мимо поля - прерывает.
+// С клавиатуры текст вводится как обычно, с геймпада крестовина вверх/вниз
+// меняет последний символ, вправо добавляет новый, влево удаляет.
+type TextInput struct {
+	Element
+
+	// MaxLength - максимальное количество символов
+	MaxLength int
+
+	// OnSubmit - функция, вызываемая при подтверждении ввода
+	OnSubmit func(text string)
+
+	// editing - идёт ли ввод; пока он идёт, поле перехватывает весь ввод
+	editing bool
+
+	// text - введённые символы
+	text []rune
+
+	// blink - счётчик кадров для мигания курсора
+	blink int
+}
+
+// NewTextInput создаёт новое поле ввода указанного размера
+func NewTextInput(width, height float64, maxLength int, onSubmit func(text string)) *TextInput {
+	input := &TextInput{
+		MaxLength: maxLength,
+		OnSubmit:  onSubmit,
+	}
+	input.Width, input.Height = width, height
+	return input
+}
+
+// Text возвращает введённый текст
+func (t *TextInput) Text() string {
+	return string(t.text)
+}
+
+// SetText заменяет введённый текст
+func (t *TextInput) SetText(text string) {
+	t.text = t.text[:0]
+	for _, r := range text {
+		t.appendRune(r)
+	}
+}
+
+// MinSize возвращает размер поля
+func (t *TextInput) MinSize() (float64, float64) {
+	return t.size(200, 40)
+}
+
+// Activate начинает ввод
+func (t *TextInput) Activate() {
+	t.editing = true
+	t.blink = 0
+}
+
+// Capturing сообщает, идёт ли ввод
+func (t *TextInput) Capturing() bool {
+	return t.editing
+}
+
+// Capture обрабатывает ввод с клавиатуры, мыши и геймпада
+func (t *TextInput) Capture() {
+	t.blink++
+
+	// Щелчок мышью мимо поля прерывает ввод
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		if !t.Rect.Contains(float64(x), float64(y)) {
+			t.editing = false
+			return
+		}
+	}
+	if BackJustPressed() {
+		t.editing = false
+		return
+	}
+
+	// Ввод символов с клавиатуры
+	for _, r := range ebiten.AppendInputChars(nil) {
+		t.appendRune(r)
+	}
+	if RepeatPressed(ebiten.KeyBackspace) {
+		t.backspace()
+	}
+
+	// Ввод с геймпада
+	if GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftTop) {
+		t.cycleLast(1)
+	}
+	if GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftBottom) {
+		t.cycleLast(-1)
+	}
+	if GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftRight) {
+		t.appendRune(gamepadAlphabet[0])
+	}
+	if GamepadButtonJustPressed(ebiten.StandardGamepadButtonLeftLeft) {
+		t.backspace()
+	}
+
+	if ConfirmJustPressed() {
+		t.editing = false
+		if t.OnSubmit != nil {
+			t.OnSubmit(t.Text())
+		}
+	}
+}
+
+// Draw отрисовывает поле ввода
+func (t *TextInput) Draw(screen *ebiten.Image) {
+	r := t.Rect
+	ebitenutil.DrawRect(screen, r.X, r.Y, r.W, r.H, fieldColor)
+
+	border := shade(borderColor, t.State())
+	if t.editing {
+		border = focusColor
+	}
+	vector.StrokeRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), 2, border, false)
+	if t.Focused() && !t.editing {
+		drawFocus(screen, r)
+	}
+
+	value := t.Text()
+	if t.editing && t.blink/30%2 == 0 {
+		value += "_"
+	}
+	text.Draw(screen, value, r.X+8, r.Y+r.H/2, text.Options{VAlign: text.AlignCenter})
+}
+
+// appendRune добавляет символ, если он печатный и есть место
+func (t *TextInput) appendRune(r rune) {
+	if !unicode.IsPrint(r) || len(t.text) >= t.MaxLength {
+		return
+	}
+	t.text = append(t.text, r)
+}
+
+// backspace удаляет последний символ
+func (t *TextInput) backspace() {
+	if len(t.text) > 0 {
+		t.text = t.text[:len(t.text)-1]
+	}
+}
+
+// cycleLast меняет последний символ на соседний в алфавите геймпада
+func (t *TextInput) cycleLast(step int) {
+	if len(t.text) == 0 {
+		t.appendRune(gamepadAlphabet[0])
+		return
+	}
+
+	last := len(t.text) - 1
+	index := 0
+	for i, r := range gamepadAlphabet {
+		if r == unicode.ToUpper(t.text[last]) {
+			index = i
+			break
+		}
+	}
+	index = (index + step + len(gamepadAlphabet)) % len(gamepadAlphabet)
+	t.text[last] = gamepadAlphabet[index]
+}
@@ -0,0 +1,283 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"superpupergame/viewport"
+)
+
+// target - виджет, доступный для фокуса, с видимой частью и списком прокрутки, в котором он лежит
+type target struct {
+	widget Focusable
+	clip   Rect
+	list   *ScrollList
+}
+
+// UI - корень дерева виджетов экрана: раскладывает виджеты, ведёт фокус
+// и раздаёт им ввод с мыши, клавиатуры и геймпада
+type UI struct {
+	// Root - корневой виджет
+	Root Widget
+
+	// Area - область экрана, в которой раскладывается корень; пустая область - весь экран,
+	// поэтому виджеты, привязанные к краям, следуют за краями при изменении размера экрана
+	Area Rect
+
+	// targets - виджеты, доступные для фокуса, в порядке обхода дерева
+	targets []target
+
+	// lists - списки прокрутки с их видимой частью
+	lists []target
+
+	// focus - виджет в фокусе
+	focus Focusable
+
+	// pressed - виджет, на котором зажата кнопка мыши
+	pressed Focusable
+
+	// cursorX, cursorY - позиция курсора на предыдущем кадре
+	cursorX, cursorY int
+}
+
+// NewUI создаёт интерфейс на весь экран игры
+func NewUI(root Widget) *UI {
+	return &UI{Root: root}
+}
+
+// area возвращает область раскладки корня
+func (u *UI) area() Rect {
+	if u.Area.W > 0 && u.Area.H > 0 {
+		return u.Area
+	}
+	w, h := viewport.Size()
+	return Rect{W: float64(w), H: float64(h)}
+}
+
+// Focus переводит фокус на виджет
+func (u *UI) Focus(w Focusable) {
+	u.focus = w
+	u.refresh()
+	for _, t := range u.targets {
+		if t.widget == w && t.list != nil {
+			t.list.ScrollTo(w.Base().Rect)
+		}
+	}
+}
+
+// Focused возвращает виджет в фокусе
+func (u *UI) Focused() Focusable {
+	return u.focus
+}
+
+// Capturing сообщает, перехватывает ли виджет в фокусе весь ввод;
+// пока это так, экран не должен обрабатывать Escape сам
+func (u *UI) Capturing() bool {
+	c, ok := u.focus.(Capturer)
+	return ok && c.Capturing()
+}
+
+// refresh раскладывает дерево и собирает доступные для фокуса виджеты.
+// Раскладка повторяется каждый кадр, поэтому смена текста или языка сразу меняет размеры.
+func (u *UI) refresh() {
+	area := u.area()
+	u.Root.Layout(area)
+	u.targets = u.targets[:0]
+	u.lists = u.lists[:0]
+	u.collect(u.Root, area, nil)
+
+	// Фокус не может остаться на скрытом или недоступном виджете
+	if u.focus != nil && !u.contains(u.focus) {
+		u.focus = nil
+	}
+	if u.focus == nil && len(u.targets) > 0 {
+		u.focus = u.targets[0].widget
+	}
+	for _, t := range u.targets {
+		t.widget.Base().focused = t.widget == u.focus
+	}
+}
+
+// collect обходит видимые виджеты, запоминая, какая их часть не обрезана списками прокрутки
+func (u *UI) collect(w Widget, clip Rect, list *ScrollList) {
+	base := w.Base()
+	base.focused = false
+	base.hovered = false
+	if base.Hidden {
+		return
+	}
+	if f, ok := w.(Focusable); ok && !base.Disabled {
+		u.targets = append(u.targets, target{widget: f, clip: clip.Intersect(base.Rect), list: list})
+	}
+	if l, ok := w.(*ScrollList); ok {
+		clip = clip.Intersect(l.Rect)
+		list = l
+		u.lists = append(u.lists, target{clip: clip, list: l})
+	}
+	if p, ok := w.(Parent); ok {
+		for _, child := range p.Children() {
+			u.collect(child, clip, list)
+		}
+	}
+}
+
+// contains сообщает, доступен ли виджет для фокуса
+func (u *UI) contains(w Focusable) bool {
+	for _, t := range u.targets {
+		if t.widget == w {
+			return true
+		}
+	}
+	return false
+}
+
+// Update раздаёт ввод виджетам
+func (u *UI) Update() {
+	u.refresh()
+
+	// Пока виджет перехватывает ввод, навигация не работает
+	if u.Capturing() {
+		u.focus.(Capturer).Capture()
+		return
+	}
+
+	u.updateKeys()
+	u.updatePointer()
+}
+
+// updateKeys обрабатывает навигацию с клавиатуры и геймпада
+func (u *UI) updateKeys() {
+	if u.focus == nil {
+		return
+	}
+	adjuster, adjustable := u.focus.(Adjuster)
+
+	switch {
+	case ConfirmJustPressed():
+		u.focus.Activate()
+	case UpJustPressed():
+		u.move(0, -1)
+	case DownJustPressed():
+		u.move(0, 1)
+	case LeftJustPressed():
+		if adjustable {
+			adjuster.Adjust(-1)
+		} else {
+			u.move(-1, 0)
+		}
+	case RightJustPressed():
+		if adjustable {
+			adjuster.Adjust(1)
+		} else {
+			u.move(1, 0)
+		}
+	}
+}
+
+// move переводит фокус на ближайший виджет в направлении (dx, dy).
+// Если в этом направлении виджетов нет, фокус переходит по кругу в порядке дерева.
+func (u *UI) move(dx, dy float64) {
+	current := -1
+	for i, t := range u.targets {
+		if t.widget == u.focus {
+			current = i
+		}
+	}
+	if current < 0 {
+		return
+	}
+
+	fx, fy := u.focus.Base().Rect.center()
+	best, bestScore := -1, math.Inf(1)
+	for i, t := range u.targets {
+		if i == current {
+			continue
+		}
+		x, y := t.widget.Base().Rect.center()
+		along := (x-fx)*dx + (y-fy)*dy
+		if along <= 1 {
+			continue
+		}
+		// Смещение поперёк направления весит больше, чтобы фокус не уходил по диагонали
+		across := math.Abs((x-fx)*dy) + math.Abs((y-fy)*dx)
+		if score := along + 2*across; score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	if best < 0 {
+		step := 1
+		if dx < 0 || dy < 0 {
+			step = -1
+		}
+		best = (current + step + len(u.targets)) % len(u.targets)
+	}
+	u.Focus(u.targets[best].widget)
+}
+
+// updatePointer обрабатывает наведение, нажатие и прокрутку мышью.
+// Нажатие срабатывает при отпускании кнопки над тем же виджетом, на котором её зажали.
+func (u *UI) updatePointer() {
+	cx, cy := ebiten.CursorPosition()
+	moved := cx != u.cursorX || cy != u.cursorY
+	u.cursorX, u.cursorY = cx, cy
+	x, y := float64(cx), float64(cy)
+
+	// Верхний виджет под курсором - последний в порядке отрисовки
+	var hit Focusable
+	for i := len(u.targets) - 1; i >= 0; i-- {
+		if u.targets[i].clip.Contains(x, y) {
+			hit = u.targets[i].widget
+			break
+		}
+	}
+
+	// Неподвижный курсор не перехватывает фокус у клавиатуры
+	if hit != nil && moved && u.pressed == nil {
+		u.Focus(hit)
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && hit != nil {
+		u.pressed = hit
+		u.Focus(hit)
+	}
+	if u.pressed != nil {
+		if d, ok := u.pressed.(Dragger); ok {
+			d.Drag(x, y)
+		}
+		u.pressed.Base().pressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	}
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && u.pressed != nil {
+		pressed := u.pressed
+		pressed.Base().pressed = false
+		u.pressed = nil
+		if pressed == hit && u.contains(pressed) {
+			pressed.Activate()
+		}
+	}
+
+	if hit != nil {
+		hit.Base().hovered = true
+	}
+
+	// Колесо прокручивает верхний список под курсором
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		for i := len(u.lists) - 1; i >= 0; i-- {
+			if u.lists[i].clip.Contains(x, y) {
+				u.lists[i].list.Scroll(-wheel * u.lists[i].list.WheelStep)
+				break
+			}
+		}
+	}
+}
+
+// Draw отрисовывает дерево виджетов и раскрытые поверх него элементы
+func (u *UI) Draw(screen *ebiten.Image) {
+	u.Root.Layout(u.area())
+	u.Root.Draw(screen)
+	if o, ok := u.focus.(Overlay); ok && u.Capturing() {
+		o.DrawOverlay(screen)
+	}
+}
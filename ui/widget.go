@@ -0,0 +1,245 @@
+// Пакет ui содержит элементы пользовательского интерфейса
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Rect - прямоугольная область экрана
+type Rect struct {
+	X, Y, W, H float64
+}
+
+// Contains проверяет, лежит ли точка внутри области
+func (r Rect) Contains(x, y float64) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
+// Inset возвращает область, уменьшенную на отступы
+func (r Rect) Inset(in Insets) Rect {
+	return Rect{
+		X: r.X + in.Left,
+		Y: r.Y + in.Top,
+		W: max(0, r.W-in.Left-in.Right),
+		H: max(0, r.H-in.Top-in.Bottom),
+	}
+}
+
+// Intersect возвращает общую часть двух областей
+func (r Rect) Intersect(o Rect) Rect {
+	x0, y0 := max(r.X, o.X), max(r.Y, o.Y)
+	x1, y1 := min(r.X+r.W, o.X+o.W), min(r.Y+r.H, o.Y+o.H)
+	return Rect{X: x0, Y: y0, W: max(0, x1-x0), H: max(0, y1-y0)}
+}
+
+// center возвращает центр области
+func (r Rect) center() (float64, float64) {
+	return r.X + r.W/2, r.Y + r.H/2
+}
+
+// Insets - внутренние отступы контейнера
+type Insets struct {
+	Top, Right, Bottom, Left float64
+}
+
+// Pad возвращает одинаковые отступы со всех сторон
+func Pad(all float64) Insets {
+	return Insets{Top: all, Right: all, Bottom: all, Left: all}
+}
+
+// Anchor - точка привязки виджета внутри рамки
+type Anchor int
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+	AnchorFill // Виджет растягивается на всю рамку
+)
+
+// Align - выравнивание дочерних виджетов поперёк направления контейнера
+type Align int
+
+const (
+	AlignStart   Align = iota // К левому (верхнему) краю
+	AlignCenter               // По центру
+	AlignEnd                  // К правому (нижнему) краю
+	AlignStretch              // На всю ширину (высоту)
+)
+
+// State - визуальное состояние виджета
+type State int
+
+const (
+	StateNormal   State = iota // Обычное
+	StateHovered               // Под курсором или в фокусе
+	StatePressed               // Нажат и ещё не отпущен
+	StateDisabled              // Недоступен
+)
+
+// Element - поля, общие для всех виджетов; встраивается в каждый виджет
+type Element struct {
+	// Rect - область виджета, назначенная при раскладке
+	Rect Rect
+
+	// Width, Height - желаемый размер; 0 - по содержимому
+	Width, Height float64
+
+	// Anchor - точка привязки внутри рамки Frame
+	Anchor Anchor
+
+	// OffsetX, OffsetY - смещение от точки привязки
+	OffsetX, OffsetY float64
+
+	// Hidden - виджет не рисуется, не занимает места и не получает ввод
+	Hidden bool
+
+	// Disabled - виджет рисуется приглушённым и не получает ввод
+	Disabled bool
+
+	// hovered, pressed, focused - состояние ввода, которое выставляет UI
+	hovered, pressed, focused bool
+}
+
+// Base возвращает общие поля виджета
+func (e *Element) Base() *Element {
+	return e
+}
+
+// Layout запоминает назначенную область; контейнеры дополнительно располагают дочерние виджеты
+func (e *Element) Layout(r Rect) {
+	e.Rect = r
+}
+
+// State возвращает визуальное состояние виджета
+func (e *Element) State() State {
+	switch {
+	case e.Disabled:
+		return StateDisabled
+	case e.pressed:
+		return StatePressed
+	case e.hovered || e.focused:
+		return StateHovered
+	default:
+		return StateNormal
+	}
+}
+
+// Focused сообщает, находится ли виджет в фокусе
+func (e *Element) Focused() bool {
+	return e.focused
+}
+
+// size применяет желаемый размер к размеру по содержимому
+func (e *Element) size(w, h float64) (float64, float64) {
+	if e.Width > 0 {
+		w = e.Width
+	}
+	if e.Height > 0 {
+		h = e.Height
+	}
+	return w, h
+}
+
+// Widget - элемент дерева интерфейса
+type Widget interface {
+	// Base возвращает общие поля виджета
+	Base() *Element
+
+	// MinSize возвращает размер виджета с учётом Width и Height
+	MinSize() (w, h float64)
+
+	// Layout располагает виджет в области r
+	Layout(r Rect)
+
+	// Draw отрисовывает виджет
+	Draw(screen *ebiten.Image)
+}
+
+// Parent - виджет, содержащий другие виджеты
+type Parent interface {
+	Children() []Widget
+}
+
+// Focusable - виджет, который получает фокус и нажимается
+// щелчком, клавишей Enter или кнопкой A геймпада
+type Focusable interface {
+	Widget
+
+	// Activate вызывается при нажатии
+	Activate()
+}
+
+// Adjuster - виджет, значение которого меняется стрелками влево и вправо
+type Adjuster interface {
+	// Adjust изменяет значение на шаг влево (-1) или вправо (+1)
+	Adjust(step int)
+}
+
+// Dragger - виджет, который следует за курсором, пока кнопка мыши зажата
+type Dragger interface {
+	// Drag вызывается с позицией курсора каждый кадр, пока виджет нажат
+	Drag(x, y float64)
+}
+
+// Capturer - виджет, который может перехватывать весь ввод,
+// например при вводе текста или назначении клавиши
+type Capturer interface {
+	// Capturing сообщает, перехватывает ли виджет ввод
+	Capturing() bool
+
+	// Capture обрабатывает ввод вместо навигации
+	Capture()
+}
+
+// Overlay - виджет, который рисует поверх всего интерфейса, пока перехватывает ввод,
+// например раскрытый список
+type Overlay interface {
+	DrawOverlay(screen *ebiten.Image)
+}
+
+// Цвета оформления
+var (
+	focusColor    = color.RGBA{255, 255, 255, 255} // Рамка виджета в фокусе
+	fieldColor    = color.RGBA{30, 30, 30, 255}    // Фон полей ввода
+	borderColor   = color.RGBA{120, 120, 120, 255} // Рамка полей ввода
+	accentColor   = color.RGBA{80, 200, 255, 255}  // Заполнение ползунков и флажков
+	disabledColor = color.RGBA{90, 90, 90, 255}    // Недоступные виджеты
+)
+
+// shade возвращает цвет виджета в указанном состоянии:
+// светлее под курсором, темнее при нажатии, серый, если виджет недоступен
+func shade(c color.RGBA, state State) color.RGBA {
+	scale := func(c color.RGBA, k float64) color.RGBA {
+		return color.RGBA{
+			R: uint8(min(255, float64(c.R)*k)),
+			G: uint8(min(255, float64(c.G)*k)),
+			B: uint8(min(255, float64(c.B)*k)),
+			A: c.A,
+		}
+	}
+	switch state {
+	case StateHovered:
+		return scale(c, 1.25)
+	case StatePressed:
+		return scale(c, 0.7)
+	case StateDisabled:
+		return disabledColor
+	default:
+		return c
+	}
+}
+
+// drawFocus рисует рамку вокруг виджета в фокусе
+func drawFocus(screen *ebiten.Image, r Rect) {
+	vector.StrokeRect(screen, float32(r.X)-2, float32(r.Y)-2, float32(r.W)+4, float32(r.H)+4, 2, focusColor, false)
+}
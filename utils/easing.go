@@ -0,0 +1,43 @@
+// Пакет utils содержит вспомогательные функции
+package utils
+
+import "math"
+
+// Easing - функция сглаживания: преобразует долю прошедшего времени t (0..1) в долю пройденного пути (0..1)
+type Easing func(t float64) float64
+
+// EaseLinear - равномерное движение
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad - плавный разгон
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad - плавное торможение
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOutQuad - плавный разгон и торможение
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// EaseOutCubic - резкий старт и долгое торможение
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// EaseInOutCubic - более выраженный, чем EaseInOutQuad, разгон и торможение
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
@@ -0,0 +1,52 @@
+// Пакет utils содержит вспомогательные функции
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// appDirName - имя каталога игры внутри каталога настроек пользователя
+const appDirName = "SuperPuperGame"
+
+// ConfigPath возвращает путь к файлу name в каталоге настроек игры
+func ConfigPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("не удалось определить каталог настроек: %w", err)
+	}
+	return filepath.Join(dir, appDirName, name), nil
+}
+
+// WriteFileAtomic записывает данные во временный файл и переименовывает его в path,
+// поэтому сбой во время записи не портит предыдущую версию файла
+func WriteFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("не удалось создать каталог %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("не удалось создать временный файл: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("не удалось записать %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("не удалось записать %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("не удалось записать %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("не удалось заменить %s: %w", path, err)
+	}
+	return nil
+}
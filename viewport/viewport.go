@@ -0,0 +1,111 @@
+// Пакет viewport отвечает за логическое разрешение экрана и размещение игрового мира на нём.
+//
+// Игра рисуется в логических пикселях. Ebiten сам растягивает логический экран на окно
+// с сохранением пропорций и сам переводит курсор в логические координаты, поэтому
+// масштаб окна, полноэкранный режим и HiDPI на координаты игры не влияют.
+// Арена - игровой мир постоянного размера - вписывается в логический экран по центру.
+package viewport
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Размер арены в мировых координатах; от него не зависит разрешение экрана
+const (
+	ArenaWidth  = 1280
+	ArenaHeight = 960
+)
+
+// Mode - способ подгонки логического экрана под окно
+type Mode int
+
+const (
+	// Letterbox сохраняет заданное разрешение; лишнее место в окне занимают чёрные полосы
+	Letterbox Mode = iota
+
+	// Expand расширяет логический экран по одной из осей до пропорций окна, чтобы полос не было
+	Expand
+)
+
+// Viewport - логическое разрешение экрана и способ его подгонки под окно
+type Viewport struct {
+	// Width, Height - заданное логическое разрешение
+	Width, Height int
+
+	// Mode - способ подгонки под окно
+	Mode Mode
+
+	// screenWidth, screenHeight - логический размер экрана после подгонки под окно
+	screenWidth, screenHeight int
+}
+
+// current - экран игры; его размер обновляется в Layout на каждом кадре
+var current = New(ArenaWidth, ArenaHeight, Letterbox)
+
+// New создаёт экран с указанным логическим разрешением
+func New(width, height int, mode Mode) *Viewport {
+	return &Viewport{Width: width, Height: height, Mode: mode, screenWidth: width, screenHeight: height}
+}
+
+// Layout подгоняет экран под окно размером outsideWidth×outsideHeight
+// и возвращает логический размер экрана для ebiten.Game.Layout
+func (v *Viewport) Layout(outsideWidth, outsideHeight int) (int, int) {
+	v.screenWidth, v.screenHeight = v.Width, v.Height
+	if v.Mode == Expand && outsideWidth > 0 && outsideHeight > 0 {
+		// Меньшая по отношению к окну ось остаётся заданной, большая растёт до пропорций окна
+		scale := math.Min(float64(outsideWidth)/float64(v.Width), float64(outsideHeight)/float64(v.Height))
+		v.screenWidth = max(v.Width, int(math.Round(float64(outsideWidth)/scale)))
+		v.screenHeight = max(v.Height, int(math.Round(float64(outsideHeight)/scale)))
+	}
+	return v.screenWidth, v.screenHeight
+}
+
+// Size возвращает логический размер экрана
+func (v *Viewport) Size() (int, int) {
+	return v.screenWidth, v.screenHeight
+}
+
+// Arena возвращает масштаб арены и положение её левого верхнего угла на экране
+func (v *Viewport) Arena() (scale, x, y float64) {
+	w, h := float64(v.screenWidth), float64(v.screenHeight)
+	scale = math.Min(w/ArenaWidth, h/ArenaHeight)
+	return scale, (w - ArenaWidth*scale) / 2, (h - ArenaHeight*scale) / 2
+}
+
+// ArenaGeoM возвращает преобразование из координат арены в координаты экрана
+func (v *Viewport) ArenaGeoM() ebiten.GeoM {
+	scale, x, y := v.Arena()
+	var m ebiten.GeoM
+	m.Scale(scale, scale)
+	m.Translate(x, y)
+	return m
+}
+
+// ScreenToArena переводит точку экрана в координаты арены
+func (v *Viewport) ScreenToArena(x, y float64) (float64, float64) {
+	scale, ax, ay := v.Arena()
+	return (x - ax) / scale, (y - ay) / scale
+}
+
+// Set делает v экраном игры
+func Set(v *Viewport) {
+	current = v
+}
+
+// Current возвращает экран игры
+func Current() *Viewport {
+	return current
+}
+
+// Size возвращает логический размер экрана игры
+func Size() (int, int) {
+	return current.Size()
+}
+
+// CursorArena возвращает позицию курсора в координатах арены
+func CursorArena() (float64, float64) {
+	x, y := ebiten.CursorPosition()
+	return current.ScreenToArena(float64(x), float64(y))
+}